@@ -0,0 +1,168 @@
+package shiori
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReferenceLabel returns semantic meaning of Reference(index) of the event in the catalog, or "" if unknown
+func ReferenceLabel(id string, index int) string {
+	info, ok := LookupEvent(id)
+	if !ok {
+		return ""
+	}
+	reference, _ := info.Reference(index)
+	return reference.Name
+}
+
+// Annotate decodes raw wire bytes of a request or response in its charset and returns annotated dump of it
+func Annotate(raw []byte) (string, error) {
+	message, err := DecodeMessage(raw)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(message, "SHIORI/") {
+		response, err := ParseResponse(message)
+		if err != nil {
+			return "", err
+		}
+		return AnnotateResponse(response), nil
+	}
+	request, err := ParseRequest(message)
+	if err != nil {
+		return "", err
+	}
+	return AnnotateRequest(request), nil
+}
+
+// AnnotateRequest returns annotated dump of the request
+func AnnotateRequest(request Request) string {
+	var builder strings.Builder
+	builder.WriteString(request.Method.String() + " " + request.Protocol.String() + "/" + request.Version + "\n")
+	writeAnnotatedHeaders(&builder, Headers(request.Headers), request.EventID())
+	return builder.String()
+}
+
+// AnnotateResponse returns annotated dump of the response
+func AnnotateResponse(response Response) string {
+	var builder strings.Builder
+	builder.WriteString(response.Protocol.String() + "/" + response.Version + " " + strconv.Itoa(response.Code) + " " + response.Message() + "\n")
+	writeAnnotatedHeaders(&builder, Headers(response.Headers), "")
+	return builder.String()
+}
+
+func writeAnnotatedHeaders(builder *strings.Builder, headers Headers, id string) {
+	if charset, ok := headers[HeaderCharset]; ok {
+		builder.WriteString("  # charset: " + charset + "\n")
+	}
+	if id != "" {
+		builder.WriteString("  # event: " + id)
+		if info, ok := LookupEvent(id); ok && info.Description != "" {
+			builder.WriteString(" (" + info.Description + ")")
+		}
+		builder.WriteString("\n")
+	}
+	for _, key := range headers.SortedKeys() {
+		builder.WriteString("  " + key + ": " + headers[key])
+		if index, ok := referenceIndex(key); ok {
+			if label := ReferenceLabel(id, index); label != "" {
+				builder.WriteString("  # " + label)
+			}
+		}
+		builder.WriteString("\n")
+	}
+}
+
+// SortedKeys returns header names; ordinary headers by name followed by Reference* headers by index.
+// Numbered ordinary headers (such as Value10 of SAORI) are ordered by the number after their prefix.
+func (headers Headers) SortedKeys() []string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		iIndex, iIsReference := referenceIndex(keys[i])
+		jIndex, jIsReference := referenceIndex(keys[j])
+		if iIsReference != jIsReference {
+			return jIsReference
+		}
+		if iIsReference {
+			return iIndex < jIndex
+		}
+		iName, iNumber := splitNumber(keys[i])
+		jName, jNumber := splitNumber(keys[j])
+		if iName != jName {
+			return iName < jName
+		}
+		return iNumber < jNumber
+	})
+	return keys
+}
+
+// WriteSorted writes headers with Charset header first and the others in SortedKeys order, except skipped ones
+func (headers Headers) WriteSorted(w io.StringWriter, skip ...string) {
+	if charset, ok := headers[HeaderCharset]; ok {
+		writeHeader(w, HeaderCharset, charset)
+	}
+	for _, key := range headers.SortedKeys() {
+		if key != HeaderCharset && !contains(skip, key) {
+			writeHeader(w, key, headers[key])
+		}
+	}
+}
+
+func writeHeader(w io.StringWriter, key string, value string) {
+	w.WriteString(key)
+	w.WriteString(": ")
+	w.WriteString(value)
+	w.WriteString("\r\n")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitNumber splits canonical decimal suffix of the header name (-1 if none)
+func splitNumber(key string) (string, int) {
+	end := len(key)
+	for end > 0 && key[end-1] >= '0' && key[end-1] <= '9' {
+		end--
+	}
+	digits := key[end:]
+	if digits == "" || (len(digits) > 1 && digits[0] == '0') {
+		return key, -1
+	}
+	number, err := strconv.Atoi(digits)
+	if err != nil {
+		return key, -1
+	}
+	return key[:end], number
+}
+
+// referenceIndex parses index of Reference* header name (canonical decimal only, e.g. not "Reference01")
+func referenceIndex(key string) (int, bool) {
+	if !strings.HasPrefix(key, HeaderReferencePrefix) {
+		return 0, false
+	}
+	digits := key[len(HeaderReferencePrefix):]
+	if digits == "" || (len(digits) > 1 && digits[0] == '0') {
+		return 0, false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	index, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
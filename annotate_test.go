@@ -0,0 +1,27 @@
+package shiori
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnnotateDecodesCharset(t *testing.T) {
+	// Reference0 is "表" in Shift_JIS
+	raw := []byte("NOTIFY SHIORI/3.0\r\nCharset: Shift_JIS\r\nID: OnBoot\r\nReference0: \x95\\\r\n\r\n")
+	dump, err := Annotate(raw)
+	if err != nil {
+		t.Fatalf("Annotate() error = %v", err)
+	}
+	for _, want := range []string{"  # charset: Shift_JIS\n", "  # event: OnBoot", "  Reference0: 表"} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("Annotate() = %q, want it to contain %q", dump, want)
+		}
+	}
+}
+
+func TestAnnotateV2Event(t *testing.T) {
+	dump := AnnotateRequest(MustParseRequest("GET Sentence SHIORI/2.2\r\nEvent: OnBoot\r\n\r\n"))
+	if !strings.Contains(dump, "  # event: OnBoot") {
+		t.Errorf("AnnotateRequest() = %q, want the event of Event header", dump)
+	}
+}
@@ -0,0 +1,75 @@
+package shiori
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+)
+
+// ReferenceInfo describes one Reference* header of an event
+type ReferenceInfo struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	// Type is "string", "int", "bool" ("0" or "1") or "list" (byte-1 separated)
+	Type string `json:"type"`
+}
+
+// EventInfo describes a standard event in the catalog
+type EventInfo struct {
+	ID          string          `json:"id"`
+	Method      string          `json:"method"`
+	Description string          `json:"description"`
+	References  []ReferenceInfo `json:"references"`
+}
+
+// Reference returns info of Reference(index)
+func (info EventInfo) Reference(index int) (ReferenceInfo, bool) {
+	for _, reference := range info.References {
+		if reference.Index == index {
+			return reference, true
+		}
+	}
+	return ReferenceInfo{}, false
+}
+
+// clone copies info so that callers cannot modify the catalog
+func (info EventInfo) clone() EventInfo {
+	info.References = append([]ReferenceInfo(nil), info.References...)
+	return info
+}
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+var catalog = loadCatalog()
+
+func loadCatalog() map[string]EventInfo {
+	var events []EventInfo
+	if err := json.Unmarshal(catalogJSON, &events); err != nil {
+		panic("shiori: broken event catalog: " + err.Error())
+	}
+	catalog := make(map[string]EventInfo, len(events))
+	for _, event := range events {
+		catalog[event.ID] = event
+	}
+	return catalog
+}
+
+// LookupEvent returns catalog info of the standard event
+func LookupEvent(id string) (EventInfo, bool) {
+	info, ok := catalog[id]
+	if !ok {
+		return info, false
+	}
+	return info.clone(), true
+}
+
+// Events returns all events in the catalog ordered by ID
+func Events() []EventInfo {
+	events := make([]EventInfo, 0, len(catalog))
+	for _, event := range catalog {
+		events = append(events, event.clone())
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events
+}
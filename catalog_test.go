@@ -0,0 +1,23 @@
+package shiori
+
+import "testing"
+
+func TestLookupEventReturnsCopy(t *testing.T) {
+	info, ok := LookupEvent("OnBoot")
+	if !ok || len(info.References) == 0 {
+		t.Fatal("OnBoot with references is not in the catalog")
+	}
+	name := info.References[0].Name
+	info.References[0].Name = "modified"
+	if got, _ := LookupEvent("OnBoot"); got.References[0].Name != name {
+		t.Errorf("catalog modified through LookupEvent: %q", got.References[0].Name)
+	}
+	for _, event := range Events() {
+		if event.ID == "OnBoot" {
+			event.References[0].Name = "modified"
+		}
+	}
+	if got, _ := LookupEvent("OnBoot"); got.References[0].Name != name {
+		t.Errorf("catalog modified through Events: %q", got.References[0].Name)
+	}
+}
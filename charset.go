@@ -0,0 +1,209 @@
+package shiori
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// LegacyCharset is assumed for messages without Charset header which are not valid UTF-8
+const LegacyCharset = "Shift_JIS"
+
+var charsetHeaderPrefix = []byte(HeaderCharset + ":")
+
+// LookupCharset returns the encoding of the Charset header value (e.g. "Shift_JIS", "UTF-8", "EUC-JP")
+func LookupCharset(charset string) (encoding.Encoding, error) {
+	enc, err := htmlindex.Get(strings.TrimSpace(charset))
+	if err != nil {
+		return nil, CharsetError("unknown charset " + charset)
+	}
+	return enc, nil
+}
+
+// DecodeRequest converts raw SHIORI/x.x Request Message in the charset declared by its Charset header into Request type
+func DecodeRequest(data []byte) (Request, error) {
+	return Parser{}.DecodeRequest(data)
+}
+
+// DecodeRequest converts raw SHIORI/x.x Request Message in the charset declared by its Charset header into Request type
+func (parser Parser) DecodeRequest(data []byte) (Request, error) {
+	message, err := DecodeMessage(data)
+	if err != nil {
+		request := Request{Protocol: SHIORI}
+		if parser.hooked() {
+			parser.notify(ParseEvent{Raw: string(data), Request: &request, Err: err})
+		}
+		return request, err
+	}
+	return parser.ParseRequest(message)
+}
+
+// DecodeResponse converts raw SHIORI/x.x Response Message in the charset declared by its Charset header into Response type
+func DecodeResponse(data []byte) (Response, error) {
+	return Parser{}.DecodeResponse(data)
+}
+
+// DecodeResponse converts raw SHIORI/x.x Response Message in the charset declared by its Charset header into Response type
+func (parser Parser) DecodeResponse(data []byte) (Response, error) {
+	message, err := DecodeMessage(data)
+	if err != nil {
+		response := Response{Protocol: SHIORI}
+		if parser.hooked() {
+			parser.notify(ParseEvent{Raw: string(data), Response: &response, Err: err})
+		}
+		return response, err
+	}
+	return parser.ParseResponse(message)
+}
+
+// Encode converts the request into SHIORI/x.x Request Message in the charset declared by its Charset header (UTF-8 if none)
+func (request Request) Encode() ([]byte, error) {
+	return EncodeMessage(request.String(), request.Charset())
+}
+
+// Encode converts the response into SHIORI/x.x Response Message in the charset declared by its Charset header (UTF-8 if none)
+func (response Response) Encode() ([]byte, error) {
+	return EncodeMessage(response.String(), response.Charset())
+}
+
+// EncodeWith is like Encode but applies the fallback to characters not representable in the charset
+func (request Request) EncodeWith(fallback CharsetFallback) ([]byte, error) {
+	return EncodeMessageWith(request.String(), request.Charset(), fallback)
+}
+
+// EncodeWith is like Encode but applies the fallback to characters not representable in the charset
+func (response Response) EncodeWith(fallback CharsetFallback) ([]byte, error) {
+	return EncodeMessageWith(response.String(), response.Charset(), fallback)
+}
+
+// DecodeMessage converts raw message (SHIORI or SSTP alike) in the charset declared by its Charset header into UTF-8 string.
+// Messages without Charset header are taken as UTF-8 if valid, LegacyCharset otherwise.
+func DecodeMessage(data []byte) (string, error) {
+	charset, ok := findCharset(data)
+	if !ok {
+		if utf8.Valid(data) {
+			return string(data), nil
+		}
+		charset = LegacyCharset
+	}
+	enc, err := LookupCharset(charset)
+	if err != nil {
+		return "", err
+	}
+	if enc == unicode.UTF8 {
+		return string(data), nil
+	}
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", CharsetError("cannot decode message as " + charset + ": " + err.Error())
+	}
+	return string(decoded), nil
+}
+
+// EncodeMessage converts message into the charset (UTF-8 if empty)
+func EncodeMessage(message string, charset string) ([]byte, error) {
+	if charset == "" {
+		return []byte(message), nil
+	}
+	enc, err := LookupCharset(charset)
+	if err != nil {
+		return nil, err
+	}
+	if enc == unicode.UTF8 {
+		return []byte(message), nil
+	}
+	encoded, err := enc.NewEncoder().Bytes([]byte(message))
+	if err != nil {
+		return nil, CharsetError("cannot encode message as " + charset + ": " + err.Error())
+	}
+	return encoded, nil
+}
+
+// CharsetFallback is policy for characters not representable in the charset of a message, such as emoji in Shift_JIS
+type CharsetFallback int
+
+const (
+	// FallbackError returns CharsetError (as EncodeMessage does)
+	FallbackError CharsetFallback = iota
+	// FallbackReplace replaces each unrepresentable character with '?'
+	FallbackReplace
+	// FallbackDrop drops unrepresentable characters
+	FallbackDrop
+	// FallbackUTF8 encodes the whole message in UTF-8 instead and rewrites its Charset header to UTF-8.
+	// It applies to unknown charsets too.
+	FallbackUTF8
+)
+
+// EncodeMessageWith is like EncodeMessage but applies the fallback when the message is not representable in the charset
+func EncodeMessageWith(message string, charset string, fallback CharsetFallback) ([]byte, error) {
+	encoded, err := EncodeMessage(message, charset)
+	if err == nil || fallback == FallbackError {
+		return encoded, err
+	}
+	if fallback == FallbackUTF8 {
+		return []byte(rewriteCharset(message, "UTF-8")), nil
+	}
+	enc, err := LookupCharset(charset)
+	if err != nil {
+		return nil, err
+	}
+	replacement := "?"
+	if fallback == FallbackDrop {
+		replacement = ""
+	}
+	return EncodeMessage(representable(message, enc, replacement), charset)
+}
+
+// representable replaces characters of message which enc cannot encode with replacement
+func representable(message string, enc encoding.Encoding, replacement string) string {
+	var builder strings.Builder
+	encoder := enc.NewEncoder()
+	for _, r := range message {
+		if _, err := encoder.String(string(r)); err != nil {
+			builder.WriteString(replacement)
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// rewriteCharset replaces Charset header value in message (its start line and headers are unchanged otherwise)
+func rewriteCharset(message string, charset string) string {
+	lines := strings.SplitAfter(message, "\r\n")
+	// skip start line
+	for i := 1; i < len(lines) && lines[i] != "\r\n"; i++ {
+		if strings.HasPrefix(lines[i], HeaderCharset+":") {
+			lines[i] = HeaderCharset + ": " + charset + "\r\n"
+			break
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// findCharset finds Charset header value in raw message bytes.
+// CR and LF never appear inside multibyte characters of supported charsets, so lines can be split before decoding.
+func findCharset(data []byte) (string, bool) {
+	lines := bytes.Split(data, []byte("\r\n"))
+	// skip start line
+	for _, line := range lines[1:] {
+		if len(line) == 0 {
+			break
+		}
+		if bytes.HasPrefix(line, charsetHeaderPrefix) {
+			return string(bytes.TrimSpace(line[len(charsetHeaderPrefix):])), true
+		}
+	}
+	return "", false
+}
+
+// CharsetError is unknown charset or message not representable in the charset
+type CharsetError string
+
+func (err CharsetError) Error() string {
+	return "CharsetError: " + string(err)
+}
@@ -0,0 +1,76 @@
+package shiori
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodeWithFallback(t *testing.T) {
+	response := OK("表😀", WithHeader(HeaderCharset, "Shift_JIS"))
+	tests := []struct {
+		name        string
+		fallback    CharsetFallback
+		wantCharset string
+		wantValue   string
+	}{
+		{"replace", FallbackReplace, "Shift_JIS", "表?"},
+		{"drop", FallbackDrop, "Shift_JIS", "表"},
+		{"UTF-8", FallbackUTF8, "UTF-8", "表😀"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := response.EncodeWith(test.fallback)
+			if err != nil {
+				t.Fatalf("EncodeWith() error = %v", err)
+			}
+			decoded, err := DecodeResponse(data)
+			if err != nil {
+				t.Fatalf("DecodeResponse() error = %v", err)
+			}
+			if decoded.Charset() != test.wantCharset || decoded.Value(0) != test.wantValue {
+				t.Errorf("EncodeWith() = %q, want Charset %s and Value %s", decoded.String(), test.wantCharset, test.wantValue)
+			}
+		})
+	}
+	if _, err := response.EncodeWith(FallbackError); !errors.Is(err, ErrCharset) {
+		t.Errorf("EncodeWith(FallbackError) error = %v, want ErrCharset", err)
+	}
+	if response.Charset() != "Shift_JIS" {
+		t.Error("EncodeWith() modified the response")
+	}
+}
+
+func TestEncodeWithUnknownCharset(t *testing.T) {
+	response := OK("表", WithHeader(HeaderCharset, "x-bogus"))
+	if _, err := response.EncodeWith(FallbackReplace); !errors.Is(err, ErrCharset) {
+		t.Errorf("EncodeWith(FallbackReplace) error = %v, want ErrCharset", err)
+	}
+	data, err := response.EncodeWith(FallbackUTF8)
+	if err != nil {
+		t.Fatalf("EncodeWith(FallbackUTF8) error = %v", err)
+	}
+	if decoded := MustParseResponse(string(data)); decoded.Charset() != "UTF-8" || decoded.Value(0) != "表" {
+		t.Errorf("EncodeWith(FallbackUTF8) = %q, want Charset UTF-8 and Value 表", data)
+	}
+}
+
+func TestDecodeHookOnCharsetError(t *testing.T) {
+	raw := "GET SHIORI/3.0\r\nCharset: x-bogus\r\nID: OnBoot\r\n\r\n"
+	var events []ParseEvent
+	parser := Parser{Hook: func(event ParseEvent) { events = append(events, event) }}
+	if _, err := parser.DecodeRequest([]byte(raw)); !errors.Is(err, ErrCharset) {
+		t.Errorf("DecodeRequest() error = %v, want ErrCharset", err)
+	}
+	if _, err := parser.DecodeResponse([]byte("SHIORI/3.0 200 OK\r\nCharset: x-bogus\r\n\r\n")); !errors.Is(err, ErrCharset) {
+		t.Errorf("DecodeResponse() error = %v, want ErrCharset", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("hook called %d times, want 2", len(events))
+	}
+	if event := events[0]; event.Raw != raw || event.Request == nil || !errors.Is(event.Err, ErrCharset) {
+		t.Errorf("request event = %+v", event)
+	}
+	if event := events[1]; event.Response == nil || !errors.Is(event.Err, ErrCharset) {
+		t.Errorf("response event = %+v", event)
+	}
+}
@@ -0,0 +1,130 @@
+// Package clock provides an injectable clock and time conditions for time-based talk.
+package clock
+
+import "time"
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// Func adapts a function to Clock
+type Func func() time.Time
+
+// Now calls the function
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System is Clock of time.Now
+var System Clock = Func(time.Now)
+
+// Fixed is Clock which always tells the same time (for tests)
+type Fixed time.Time
+
+// Now returns the fixed time
+func (fixed Fixed) Now() time.Time {
+	return time.Time(fixed)
+}
+
+// Condition is a predicate over time
+type Condition func(t time.Time) bool
+
+// Now evaluates the condition at the current time of the clock
+func (condition Condition) Now(clock Clock) bool {
+	return condition(clock.Now())
+}
+
+// And is satisfied when all conditions are satisfied
+func And(conditions ...Condition) Condition {
+	return func(t time.Time) bool {
+		for _, condition := range conditions {
+			if !condition(t) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or is satisfied when any condition is satisfied
+func Or(conditions ...Condition) Condition {
+	return func(t time.Time) bool {
+		for _, condition := range conditions {
+			if condition(t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not negates the condition
+func Not(condition Condition) Condition {
+	return func(t time.Time) bool {
+		return !condition(t)
+	}
+}
+
+// TimeOfDay is wall clock time in a day
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+func (timeOfDay TimeOfDay) minutes() int {
+	return timeOfDay.Hour*60 + timeOfDay.Minute
+}
+
+// TimeRange is [Start, End) in a day; it wraps past midnight when End is not after Start
+type TimeRange struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// Between makes TimeRange from hours, e.g. Between(5, 10) for mornings or Between(22, 5) for nights
+func Between(startHour int, endHour int) TimeRange {
+	return TimeRange{Start: TimeOfDay{Hour: startHour}, End: TimeOfDay{Hour: endHour}}
+}
+
+// Contains reports whether the range contains wall clock time of t
+func (timeRange TimeRange) Contains(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	start := timeRange.Start.minutes()
+	end := timeRange.End.minutes()
+	if start < end {
+		return start <= minutes && minutes < end
+	}
+	return minutes >= start || minutes < end
+}
+
+// Condition of the range
+func (timeRange TimeRange) Condition() Condition {
+	return timeRange.Contains
+}
+
+// Weekdays is set of days of week
+type Weekdays []time.Weekday
+
+var (
+	// Workdays is Monday to Friday
+	Workdays = Weekdays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	// Weekend is Saturday and Sunday
+	Weekend = Weekdays{time.Saturday, time.Sunday}
+)
+
+// Contains reports whether the day of week of t is in the set
+func (weekdays Weekdays) Contains(t time.Time) bool {
+	weekday := t.Weekday()
+	for _, day := range weekdays {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Condition of the set
+func (weekdays Weekdays) Condition() Condition {
+	return weekdays.Contains
+}
@@ -0,0 +1,194 @@
+package clock
+
+import (
+	"strconv"
+	"time"
+)
+
+// Era is a Japanese era
+type Era struct {
+	Name string
+	// Start is the first day of the era (in JST)
+	Start time.Time
+}
+
+// JST is Japan Standard Time, in which eras and holidays are reckoned
+var JST = time.FixedZone("JST", 9*60*60)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, JST)
+}
+
+// Eras are Japanese eras since the adoption of the Gregorian calendar, newest first
+var Eras = []Era{
+	{Name: "令和", Start: date(2019, time.May, 1)},
+	{Name: "平成", Start: date(1989, time.January, 8)},
+	{Name: "昭和", Start: date(1926, time.December, 25)},
+	{Name: "大正", Start: date(1912, time.July, 30)},
+	{Name: "明治", Start: date(1868, time.October, 23)},
+}
+
+// JapaneseEra returns the era and its year (1 for 元年) of t; ok is false before Meiji
+func JapaneseEra(t time.Time) (era Era, year int, ok bool) {
+	t = t.In(JST)
+	for _, era := range Eras {
+		if !t.Before(era.Start) {
+			return era, t.Year() - era.Start.Year() + 1, true
+		}
+	}
+	return Era{}, 0, false
+}
+
+// FormatJapaneseYear formats the year of t like "令和元年" or "令和6年"; empty before Meiji
+func FormatJapaneseYear(t time.Time) string {
+	era, year, ok := JapaneseEra(t)
+	if !ok {
+		return ""
+	}
+	if year == 1 {
+		return era.Name + "元年"
+	}
+	return era.Name + strconv.Itoa(year) + "年"
+}
+
+// nthWeekday returns day of month of the nth weekday
+func nthWeekday(year int, month time.Month, weekday time.Weekday, nth int) int {
+	first := date(year, month, 1).Weekday()
+	return 1 + (int(weekday)-int(first)+7)%7 + (nth-1)*7
+}
+
+// equinoxDay approximates equinox day (valid for 1980-2099)
+func equinoxDay(year int, base float64) int {
+	return int(base + 0.242194*float64(year-1980) - float64((year-1980)/4))
+}
+
+// olympicHolidays are July/August days of holidays moved for the Tokyo Olympics
+var olympicHolidays = map[int]struct{ marine, sports, mountain int }{
+	2020: {marine: 23, sports: 24, mountain: 10},
+	2021: {marine: 22, sports: 23, mountain: 8},
+}
+
+// namedHoliday returns name of national holiday defined by date (without substitute holidays)
+func namedHoliday(year int, month time.Month, day int) string {
+	if year == 2019 {
+		switch {
+		case month == time.May && day == 1:
+			return "天皇の即位の日"
+		case month == time.October && day == 22:
+			return "即位礼正殿の儀の行われる日"
+		}
+	}
+	moved, isMoved := olympicHolidays[year]
+	switch month {
+	case time.January:
+		if day == 1 {
+			return "元日"
+		}
+		if day == nthWeekday(year, month, time.Monday, 2) {
+			return "成人の日"
+		}
+	case time.February:
+		if day == 11 {
+			return "建国記念の日"
+		}
+		if day == 23 && year >= 2020 {
+			return "天皇誕生日"
+		}
+	case time.March:
+		if day == equinoxDay(year, 20.8431) {
+			return "春分の日"
+		}
+	case time.April:
+		if day == 29 {
+			return "昭和の日"
+		}
+	case time.May:
+		switch day {
+		case 3:
+			return "憲法記念日"
+		case 4:
+			return "みどりの日"
+		case 5:
+			return "こどもの日"
+		}
+	case time.July:
+		if isMoved {
+			switch day {
+			case moved.marine:
+				return "海の日"
+			case moved.sports:
+				return "スポーツの日"
+			}
+		} else if day == nthWeekday(year, month, time.Monday, 3) {
+			return "海の日"
+		}
+	case time.August:
+		if isMoved {
+			if day == moved.mountain {
+				return "山の日"
+			}
+		} else if day == 11 && year >= 2016 {
+			return "山の日"
+		}
+	case time.September:
+		if day == nthWeekday(year, month, time.Monday, 3) {
+			return "敬老の日"
+		}
+		if day == equinoxDay(year, 23.2488) {
+			return "秋分の日"
+		}
+	case time.October:
+		if !isMoved && day == nthWeekday(year, month, time.Monday, 2) {
+			if year >= 2020 {
+				return "スポーツの日"
+			}
+			return "体育の日"
+		}
+	case time.November:
+		switch day {
+		case 3:
+			return "文化の日"
+		case 23:
+			return "勤労感謝の日"
+		}
+	case time.December:
+		if day == 23 && year >= 1989 && year <= 2018 {
+			return "天皇誕生日"
+		}
+	}
+	return ""
+}
+
+// JapaneseHoliday returns name of the Japanese national holiday on the date of t (in JST), or "" if not a holiday.
+// Rules are those in force since 2007 and the result is meaningful for 2007-2099.
+func JapaneseHoliday(t time.Time) string {
+	t = t.In(JST)
+	year, month, day := t.Date()
+	if name := namedHoliday(year, month, day); name != "" {
+		return name
+	}
+	// substitute holiday: the first non-holiday after a holiday on Sunday
+	for previous := t.AddDate(0, 0, -1); ; previous = previous.AddDate(0, 0, -1) {
+		y, m, d := previous.Date()
+		if namedHoliday(y, m, d) == "" {
+			break
+		}
+		if previous.Weekday() == time.Sunday {
+			return "振替休日"
+		}
+	}
+	// citizens' holiday: a day between two holidays
+	before := t.AddDate(0, 0, -1)
+	after := t.AddDate(0, 0, 1)
+	by, bm, bd := before.Date()
+	ay, am, ad := after.Date()
+	if namedHoliday(by, bm, bd) != "" && namedHoliday(ay, am, ad) != "" && t.Weekday() != time.Sunday {
+		return "国民の休日"
+	}
+	return ""
+}
+
+// IsJapaneseHoliday reports whether t is on a Japanese national holiday
+func IsJapaneseHoliday(t time.Time) bool {
+	return JapaneseHoliday(t) != ""
+}
@@ -0,0 +1,95 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapaneseHoliday(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		// 2019: the enthronement and the 10-day Golden Week
+		{"2019-01-14", "成人の日"},
+		{"2019-02-23", ""},
+		{"2019-03-21", "春分の日"},
+		{"2019-04-30", "国民の休日"},
+		{"2019-05-01", "天皇の即位の日"},
+		{"2019-05-02", "国民の休日"},
+		{"2019-05-06", "振替休日"},
+		{"2019-08-12", "振替休日"},
+		{"2019-09-23", "秋分の日"},
+		{"2019-10-14", "体育の日"},
+		{"2019-10-22", "即位礼正殿の儀の行われる日"},
+		{"2019-11-04", "振替休日"},
+		{"2019-12-23", ""},
+		// 2020: the Olympic moves
+		{"2020-02-23", "天皇誕生日"},
+		{"2020-02-24", "振替休日"},
+		{"2020-03-20", "春分の日"},
+		{"2020-05-06", "振替休日"},
+		{"2020-07-20", ""},
+		{"2020-07-23", "海の日"},
+		{"2020-07-24", "スポーツの日"},
+		{"2020-08-10", "山の日"},
+		{"2020-08-11", ""},
+		{"2020-09-21", "敬老の日"},
+		{"2020-09-22", "秋分の日"},
+		{"2020-10-12", ""},
+		// 2024
+		{"2024-02-12", "振替休日"},
+		{"2024-03-20", "春分の日"},
+		{"2024-05-06", "振替休日"},
+		{"2024-07-15", "海の日"},
+		{"2024-08-12", "振替休日"},
+		{"2024-09-16", "敬老の日"},
+		{"2024-09-22", "秋分の日"},
+		{"2024-09-23", "振替休日"},
+		{"2024-10-14", "スポーツの日"},
+		{"2024-11-04", "振替休日"},
+		// 2026: the silver week
+		{"2026-01-12", "成人の日"},
+		{"2026-03-20", "春分の日"},
+		{"2026-05-06", "振替休日"},
+		{"2026-09-21", "敬老の日"},
+		{"2026-09-22", "国民の休日"},
+		{"2026-09-23", "秋分の日"},
+		{"2026-10-12", "スポーツの日"},
+		{"2026-10-14", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			day, err := time.ParseInLocation("2006-01-02", test.date, JST)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := JapaneseHoliday(day.Add(12 * time.Hour)); got != test.want {
+				t.Errorf("JapaneseHoliday(%s) = %q, want %q", test.date, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatJapaneseYear(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"last day of Showa", date(1989, time.January, 7), "昭和64年"},
+		{"first day of Heisei", date(1989, time.January, 8), "平成元年"},
+		{"last day of Heisei", date(2019, time.April, 30), "平成31年"},
+		{"first day of Reiwa", date(2019, time.May, 1), "令和元年"},
+		{"first day of Reiwa in UTC", time.Date(2019, time.April, 30, 15, 0, 0, 0, time.UTC), "令和元年"},
+		{"Reiwa", date(2026, time.October, 14), "令和8年"},
+		{"before Meiji", date(1868, time.October, 22), ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := FormatJapaneseYear(test.t); got != test.want {
+				t.Errorf("FormatJapaneseYear() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Command shiori-eventgen generates typed event structs, decoders and request constructors
+// from the shiori event catalog, extended by a catalog file in the same JSON format.
+//
+// Usage:
+//
+//	//go:generate shiori-eventgen -package ghost -catalog events.json -events OnMyEvent,OnOtherEvent -o events_gen.go
+//
+// Events of the catalog file are merged over the standard catalog, replacing standard events of the same ID.
+// For each event it generates type <Event>, func Decode<Event>(shiori.Request) (<Event>, error)
+// and func New<Event>Request(...) shiori.Request.
+// The types implement events.Event, so that they can be registered with events.On,
+// and the decoders share helpers of the events package, so that multiple generated files can live in one package.
+// Decoding errors are events.DecodeError.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func main() {
+	catalogPath := flag.String("catalog", "", "catalog JSON file (the standard catalog when empty)")
+	packageName := flag.String("package", "events", "package name of the generated file")
+	output := flag.String("o", "", "output file (stdout when empty)")
+	only := flag.String("events", "", "comma separated event IDs to generate (all when empty)")
+	flag.Parse()
+
+	events, err := loadEvents(*catalogPath)
+	if err != nil {
+		fail(err)
+	}
+	events = filterEvents(events, *only)
+	source, err := generate(*packageName, events)
+	if err != nil {
+		fail(err)
+	}
+	if *output == "" {
+		os.Stdout.Write(source)
+		return
+	}
+	if err := os.WriteFile(*output, source, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "shiori-eventgen:", err)
+	os.Exit(1)
+}
+
+func loadEvents(path string) ([]shiori.EventInfo, error) {
+	events := shiori.Events()
+	if path == "" {
+		return events, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var extensions []shiori.EventInfo
+	if err := json.Unmarshal(data, &extensions); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return mergeEvents(events, extensions), nil
+}
+
+// mergeEvents replaces events by the extensions of the same ID and appends the other extensions
+func mergeEvents(events []shiori.EventInfo, extensions []shiori.EventInfo) []shiori.EventInfo {
+	indexes := make(map[string]int, len(events))
+	for i, event := range events {
+		indexes[event.ID] = i
+	}
+	for _, extension := range extensions {
+		if i, ok := indexes[extension.ID]; ok {
+			events[i] = extension
+			continue
+		}
+		indexes[extension.ID] = len(events)
+		events = append(events, extension)
+	}
+	return events
+}
+
+func filterEvents(events []shiori.EventInfo, only string) []shiori.EventInfo {
+	if only == "" {
+		return events
+	}
+	wanted := map[string]bool{}
+	for _, id := range strings.Split(only, ",") {
+		wanted[strings.TrimSpace(id)] = true
+	}
+	var filtered []shiori.EventInfo
+	for _, event := range events {
+		if wanted[event.ID] {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+type eventData struct {
+	shiori.EventInfo
+	TypeName   string
+	MethodName string
+	Fields     []fieldData
+}
+
+type fieldData struct {
+	shiori.ReferenceInfo
+	FieldName string
+	GoType    string
+	ParamName string
+}
+
+// identifier converts free text such as "uptime (hours)" or "OnUpdate.OnDownloadBegin" into UptimeHours / OnUpdateOnDownloadBegin
+func identifier(text string) string {
+	var builder strings.Builder
+	upper := true
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	name := builder.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+	switch name {
+	case "type", "func", "range", "map", "chan", "go", "select", "case", "default", "var", "const", "package", "import", "return", "if", "else", "for", "switch", "break", "continue", "defer", "goto", "interface", "struct", "fallthrough", "request", "event", "err", "shiori", "events", "strconv", "strings":
+		return name + "Value"
+	}
+	return name
+}
+
+func goType(referenceType string) string {
+	switch referenceType {
+	case "int":
+		return "int"
+	case "bool":
+		return "bool"
+	case "list":
+		return "[]string"
+	default:
+		return "string"
+	}
+}
+
+func generate(packageName string, events []shiori.EventInfo) ([]byte, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no events to generate")
+	}
+	data := make([]eventData, 0, len(events))
+	imports := map[string]bool{}
+	for _, event := range events {
+		typeName := identifier(event.ID)
+		method := "GET"
+		if event.Method == "NOTIFY" {
+			method = "NOTIFY"
+		}
+		fields := make([]fieldData, 0, len(event.References))
+		for _, reference := range event.References {
+			fieldName := identifier(reference.Name)
+			switch goType(reference.Type) {
+			case "int":
+				imports["strconv"] = true
+			case "[]string":
+				imports["strings"] = true
+			}
+			fields = append(fields, fieldData{
+				ReferenceInfo: reference,
+				FieldName:     fieldName,
+				GoType:        goType(reference.Type),
+				ParamName:     lowerFirst(fieldName),
+			})
+		}
+		data = append(data, eventData{EventInfo: event, TypeName: typeName, MethodName: method, Fields: fields})
+	}
+	var buffer bytes.Buffer
+	if err := sourceTemplate.Execute(&buffer, struct {
+		Package string
+		Imports map[string]bool
+		Events  []eventData
+	}{packageName, imports, data}); err != nil {
+		return nil, err
+	}
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated source is broken: %w\n%s", err, buffer.Bytes())
+	}
+	return source, nil
+}
+
+var sourceTemplate = template.Must(template.New("source").Parse(`// Code generated by shiori-eventgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .Imports.strconv}}
+	"strconv"
+{{- end}}
+{{- if .Imports.strings}}
+	"strings"
+{{- end}}
+
+	shiori "github.com/Narazaka/shiorigo"
+	"github.com/Narazaka/shiorigo/events"
+)
+{{range .Events}}{{$event := .}}
+// {{.TypeName}} is {{.ID}} event{{if .Description}} ({{.Description}}){{end}}
+type {{.TypeName}} struct {
+{{- range .Fields}}
+	// {{.FieldName}} is {{.Name}} (Reference{{.Index}})
+	{{.FieldName}} {{.GoType}}
+{{- end}}
+}
+
+// Decode{{.TypeName}} decodes {{.ID}} request
+func Decode{{.TypeName}}(request shiori.Request) ({{.TypeName}}, error) {
+	var event {{.TypeName}}
+	if err := events.CheckID(request, {{printf "%q" .ID}}); err != nil {
+		return event, err
+	}
+{{- range .Fields}}
+{{- if eq .GoType "int"}}
+	{{.ParamName}}, err := events.ParseIntReference(request, {{.Index}})
+	if err != nil {
+		return event, err
+	}
+	event.{{.FieldName}} = {{.ParamName}}
+{{- else if eq .GoType "bool"}}
+	{{.ParamName}}, err := events.ParseBoolReference(request, {{.Index}})
+	if err != nil {
+		return event, err
+	}
+	event.{{.FieldName}} = {{.ParamName}}
+{{- else if eq .GoType "[]string"}}
+	event.{{.FieldName}} = events.SplitListReference(request, {{.Index}})
+{{- else}}
+	event.{{.FieldName}} = request.Reference({{.Index}})
+{{- end}}
+{{- end}}
+	return event, nil
+}
+
+// EventID is {{printf "%q" .ID}}
+func (*{{.TypeName}}) EventID() string {
+	return {{printf "%q" .ID}}
+}
+
+// Decode sets the event decoded by Decode{{.TypeName}} (for events.On)
+func (event *{{.TypeName}}) Decode(request shiori.Request) (err error) {
+	*event, err = Decode{{.TypeName}}(request)
+	return err
+}
+
+// New{{.TypeName}}Request makes {{.MethodName}} {{.ID}} request
+func New{{.TypeName}}Request({{range $i, $field := .Fields}}{{if $i}}, {{end}}{{.ParamName}} {{.GoType}}{{end}}) shiori.Request {
+	request := shiori.NewRequest(shiori.{{.MethodName}}, {{printf "%q" .ID}})
+{{- range .Fields}}
+{{- if eq .GoType "int"}}
+	request.Headers[shiori.HeaderReferencePrefix+"{{.Index}}"] = strconv.Itoa({{.ParamName}})
+{{- else if eq .GoType "bool"}}
+	request.Headers[shiori.HeaderReferencePrefix+"{{.Index}}"] = events.FormatBoolReference({{.ParamName}})
+{{- else if eq .GoType "[]string"}}
+	request.Headers[shiori.HeaderReferencePrefix+"{{.Index}}"] = strings.Join({{.ParamName}}, "\x01")
+{{- else}}
+	request.Headers[shiori.HeaderReferencePrefix+"{{.Index}}"] = {{.ParamName}}
+{{- end}}
+{{- end}}
+	return request
+}
+{{end}}`))
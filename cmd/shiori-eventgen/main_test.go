@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerateGolden(t *testing.T) {
+	events, err := loadEvents(filepath.Join("testdata", "custom.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := generate("ghost", filterEvents(events, "OnBoot, OnMyGhost.Feed"))
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	golden := filepath.Join("testdata", "custom.golden")
+	if *update {
+		if err := os.WriteFile(golden, source, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(source) != string(want) {
+		t.Errorf("generated source differs from %s (run go test -update to accept):\n%s", golden, source)
+	}
+}
+
+func TestLoadEventsMerges(t *testing.T) {
+	events, err := loadEvents(filepath.Join("testdata", "custom.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string]int{}
+	for _, event := range events {
+		byID[event.ID]++
+	}
+	standard, err := loadEvents("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(standard)+1 || byID["OnBoot"] != 1 || byID["OnMyGhost.Feed"] != 1 || byID["OnClose"] != 1 {
+		t.Errorf("loadEvents() = %d events (%d standard), want the standard ones plus OnMyGhost.Feed with OnBoot replaced", len(events), len(standard))
+	}
+	for _, event := range events {
+		if event.ID == "OnBoot" && len(event.References) != 2 {
+			t.Errorf("OnBoot = %+v, want the one of the catalog file", event)
+		}
+	}
+}
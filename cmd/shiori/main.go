@@ -0,0 +1,101 @@
+// Command shiori validates and pretty-prints SHIORI messages.
+//
+// Usage:
+//
+//	shiori [-q] [-to 2|3] [file ...]
+//
+// Each file (or stdin when no file is given) must hold one SHIORI request or
+// response. Messages written with bare LF line endings are accepted as well.
+// Messages are decoded from the charset of their Charset header.
+// With -to, messages are converted to SHIORI/2.x or SHIORI/3.0 form before printing.
+// The exit status is 1 if any message fails to parse or convert.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func main() {
+	quiet := flag.Bool("q", false, "only validate, print nothing but errors")
+	to := flag.String("to", "", "convert to SHIORI/2.x (2) or SHIORI/3.0 (3) form")
+	flag.Parse()
+	if *to != "" && *to != "2" && *to != "3" {
+		fmt.Fprintln(os.Stderr, "-to must be 2 or 3")
+		os.Exit(2)
+	}
+
+	ok := true
+	if flag.NArg() == 0 {
+		ok = run("<stdin>", os.Stdin, *quiet, *to)
+	}
+	for _, name := range flag.Args() {
+		file, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			ok = false
+			continue
+		}
+		if !run(name, file, *quiet, *to) {
+			ok = false
+		}
+		file.Close()
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func run(name string, reader io.Reader, quiet bool, to string) bool {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+		return false
+	}
+	if !bytes.Contains(data, []byte("\r\n")) {
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
+
+	var dump string
+	if bytes.HasPrefix(data, []byte("SHIORI/")) {
+		response, err := shiori.DecodeResponse(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+			return false
+		}
+		switch to {
+		case "2":
+			response = response.ToV2()
+		case "3":
+			response = response.ToV3()
+		}
+		dump = shiori.AnnotateResponse(response)
+	} else {
+		request, err := shiori.DecodeRequest(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+			return false
+		}
+		switch to {
+		case "2":
+			request, err = request.ToV2()
+		case "3":
+			request, err = request.ToV3()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+			return false
+		}
+		dump = shiori.AnnotateRequest(request)
+	}
+	if quiet {
+		return true
+	}
+	fmt.Printf("# %s\n%s", name, dump)
+	return true
+}
@@ -0,0 +1,148 @@
+// Command sstp sends an SSTP request to a running baseware and prints the response.
+//
+// Usage:
+//
+//	sstp [-addr host:port] [-http] [-method SEND|NOTIFY|EXECUTE] [-script script] [-event id]
+//	     [-r reference ...] [-command command] [-H "Key: Value" ...] [-charset charset] [-sender name]
+//
+// The request goes over TCP to addr (SSP and other basewares listen on 127.0.0.1:9801),
+// or with -http, is posted to http://addr/api/sstp/v1 as SSP accepts.
+// DirectSSTP (window messages) is not supported.
+// References given by -r are numbered Reference0, Reference1, ... in order.
+// The request is encoded in the charset and the response is decoded from the charset of its Charset header.
+// The exit status is 1 if sending fails or the response is 4xx or 5xx.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	shiori "github.com/Narazaka/shiorigo"
+	"github.com/Narazaka/shiorigo/sstp"
+)
+
+// stringList is a flag which may be given multiple times
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9801", "address of the baseware")
+	useHTTP := flag.Bool("http", false, "post the request over HTTP instead of TCP")
+	methodName := flag.String("method", "SEND", "SSTP method (SEND, NOTIFY, COMMUNICATE, EXECUTE or GIVE)")
+	script := flag.String("script", "", "Script header")
+	event := flag.String("event", "", "Event header")
+	command := flag.String("command", "", "Command header")
+	charset := flag.String("charset", "UTF-8", "Charset header")
+	sender := flag.String("sender", "sstp", "Sender header")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of the whole exchange")
+	var references, headers stringList
+	flag.Var(&references, "r", "Reference* header (repeatable)")
+	flag.Var(&headers, "H", `extra "Key: Value" header (repeatable)`)
+	flag.Parse()
+
+	method, err := sstp.ToMethod(strings.ToUpper(*methodName))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	request := sstp.NewRequest(method, shiori.Headers{
+		sstp.HeaderCharset: *charset,
+		sstp.HeaderSender:  *sender,
+	})
+	for key, value := range map[string]string{sstp.HeaderScript: *script, sstp.HeaderEvent: *event, sstp.HeaderCommand: *command} {
+		if value != "" {
+			request.Headers[key] = value
+		}
+	}
+	for i, reference := range references {
+		request.Headers[sstp.HeaderReferencePrefix+strconv.Itoa(i)] = reference
+	}
+	for _, header := range headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "-H %q must be \"Key: Value\"\n", header)
+			os.Exit(2)
+		}
+		request.Headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	data, err := request.Encode()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *useHTTP {
+		data, err = sendHTTP(*addr, data, *timeout)
+	} else {
+		data, err = sendTCP(*addr, data, *timeout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	response, err := sstp.DecodeResponse(data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(strings.ReplaceAll(response.String(), "\r\n", "\n"))
+	if response.Code >= 400 {
+		os.Exit(1)
+	}
+}
+
+// sendTCP writes the request and reads the response up to the blank line or the end of the connection
+func sendTCP(addr string, data []byte, timeout time.Duration) ([]byte, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(data); err != nil {
+		return nil, err
+	}
+	scanner := shiori.NewScanner(conn)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	return scanner.Bytes(), nil
+}
+
+// sendHTTP posts the request to the SSTP endpoint of SSP
+func sendHTTP(addr string, data []byte, timeout time.Duration) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodPost, "http://"+addr+"/api/sstp/v1", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "text/plain")
+	request.Header.Set("Origin", "http://"+addr)
+	client := http.Client{Timeout: timeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return io.ReadAll(response.Body)
+}
@@ -0,0 +1,16 @@
+package shiori
+
+import "context"
+
+type dryRunKey struct{}
+
+// WithDryRun returns context marked as dry-run (e.g. by testing tools replaying events)
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx is marked as dry-run; handlers should then skip persisting state and other side effects
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
@@ -0,0 +1,153 @@
+package shiori
+
+import "strings"
+
+// DefaultV2Version is SHIORI version of requests and responses converted by ToV2
+const DefaultV2Version = "2.6"
+
+// ConvertError is a message which has no counterpart in the other SHIORI version
+type ConvertError string
+
+func (err ConvertError) Error() string {
+	return "ConvertError: " + string(err)
+}
+
+// convertedHeaders are headers kept as is by the conversion
+var convertedHeaders = []string{HeaderCharset, HeaderSender, HeaderSecurityLevel}
+
+func convertRequestHeaders(headers RequestHeaders) RequestHeaders {
+	converted := RequestHeaders{}
+	for _, key := range convertedHeaders {
+		if value, ok := headers[key]; ok {
+			converted[key] = value
+		}
+	}
+	return converted
+}
+
+func copyReferences(to RequestHeaders, from RequestHeaders) {
+	for key, value := range from {
+		if _, ok := referenceIndex(key); ok {
+			to[key] = value
+		}
+	}
+}
+
+// ToV3 converts SHIORI/2.x request into SHIORI/3.0 form; SHIORI/3.0 request is returned as is.
+//
+// GET Sentence with Event becomes GET of the event, GET Sentence with Sentence becomes OnCommunicate
+// and the other GET Sentence becomes OnAITalk. GET Word, GET Status and TEACH have no SHIORI/3.0 form.
+func (request Request) ToV3() (Request, error) {
+	if !request.Method.IsV2() {
+		return request, nil
+	}
+	headers := convertRequestHeaders(request.Headers)
+	converted := Request{Method: GET, Protocol: SHIORI, Version: DefaultVersion, Headers: headers}
+	switch request.Method {
+	case GETVersion:
+		headers[HeaderID] = "version"
+	case GETSentence:
+		if event, ok := request.Headers[HeaderEvent]; ok {
+			headers[HeaderID] = event
+			copyReferences(headers, request.Headers)
+		} else if sentence, ok := request.Headers[HeaderSentence]; ok {
+			// Sender is the talking ghost here, not the baseware
+			delete(headers, HeaderSender)
+			headers[HeaderID] = "OnCommunicate"
+			headers[HeaderReferencePrefix+"0"] = request.Headers[HeaderSender]
+			headers[HeaderReferencePrefix+"1"] = sentence
+		} else {
+			headers[HeaderID] = "OnAITalk"
+		}
+	case GETString:
+		headers[HeaderID] = request.Headers[HeaderString]
+	case NOTIFYOwnerGhostName:
+		converted.Method = NOTIFY
+		headers[HeaderID] = "ownerghostname"
+		headers[HeaderReferencePrefix+"0"] = request.Headers[HeaderGhost]
+	case NOTIFYOtherGhostName:
+		converted.Method = NOTIFY
+		headers[HeaderID] = "otherghostname"
+		headers[HeaderReferencePrefix+"0"] = request.Headers[HeaderGhost]
+	case TRANSLATESentence:
+		headers[HeaderID] = "OnTranslate"
+		headers[HeaderReferencePrefix+"0"] = request.Headers[HeaderSentence]
+	default:
+		return request, ConvertError("no SHIORI/3.0 form of " + request.Method.String())
+	}
+	return converted, nil
+}
+
+// ToV2 converts SHIORI/3.0 request into SHIORI/2.x form; SHIORI/2.x request is returned as is.
+//
+// It is the reverse of ToV3; other events become GET Sentence with Event and other resources GET String.
+func (request Request) ToV2() (Request, error) {
+	if request.Method.IsV2() {
+		return request, nil
+	}
+	headers := convertRequestHeaders(request.Headers)
+	converted := Request{Method: GETSentence, Protocol: SHIORI, Version: DefaultV2Version, Headers: headers}
+	id := request.Headers[HeaderID]
+	switch {
+	case id == "version" && request.Method == GET:
+		converted.Method = GETVersion
+	case id == "ownerghostname":
+		converted.Method = NOTIFYOwnerGhostName
+		headers[HeaderGhost] = request.Reference(0)
+	case id == "otherghostname":
+		converted.Method = NOTIFYOtherGhostName
+		headers[HeaderGhost] = request.Reference(0)
+	case id == "OnCommunicate":
+		headers[HeaderSender] = request.Reference(0)
+		headers[HeaderSentence] = request.Reference(1)
+	case id == "OnTranslate":
+		converted.Method = TRANSLATESentence
+		headers[HeaderSentence] = request.Reference(0)
+	case id == "OnAITalk":
+	case strings.HasPrefix(id, "On"):
+		headers[HeaderEvent] = id
+		copyReferences(headers, request.Headers)
+	case id != "" && request.Method == GET:
+		converted.Method = GETString
+		headers[HeaderString] = id
+	default:
+		return request, ConvertError("no SHIORI/2.x form of " + request.Method.String() + " " + id)
+	}
+	return converted, nil
+}
+
+// ToV3 converts SHIORI/2.x response into SHIORI/3.0 form; SHIORI/3.0 response is returned as is.
+// Sentence, Word, String or Status header becomes Value header.
+func (response Response) ToV3() Response {
+	if !response.IsV2() {
+		return response
+	}
+	headers := ResponseHeaders(Headers(response.Headers).Clone())
+	for _, key := range []string{HeaderSentence, HeaderWord, HeaderString, HeaderStatus} {
+		if value, ok := headers[key]; ok {
+			delete(headers, key)
+			if _, ok := headers[HeaderValue]; !ok {
+				headers[HeaderValue] = value
+			}
+		}
+	}
+	response.Version = DefaultVersion
+	response.Headers = headers
+	return response
+}
+
+// ToV2 converts SHIORI/3.0 response into SHIORI/2.x form; SHIORI/2.x response is returned as is.
+// Value header becomes Sentence header.
+func (response Response) ToV2() Response {
+	if response.IsV2() {
+		return response
+	}
+	headers := ResponseHeaders(Headers(response.Headers).Clone())
+	if value, ok := headers[HeaderValue]; ok {
+		delete(headers, HeaderValue)
+		headers[HeaderSentence] = value
+	}
+	response.Version = DefaultV2Version
+	response.Headers = headers
+	return response
+}
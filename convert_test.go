@@ -0,0 +1,52 @@
+package shiori
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRequestConversion(t *testing.T) {
+	tests := []struct {
+		name string
+		v2   string
+		v3   string
+	}{
+		{"version", "GET Version SHIORI/2.6\r\nCharset: UTF-8\r\n\r\n", "GET SHIORI/3.0\r\nCharset: UTF-8\r\nID: version\r\n\r\n"},
+		{"event", "GET Sentence SHIORI/2.6\r\nEvent: OnBoot\r\nReference0: master\r\n\r\n", "GET SHIORI/3.0\r\nID: OnBoot\r\nReference0: master\r\n\r\n"},
+		{"communicate", "GET Sentence SHIORI/2.6\r\nSender: sakura\r\nSentence: hello\r\n\r\n", "GET SHIORI/3.0\r\nID: OnCommunicate\r\nReference0: sakura\r\nReference1: hello\r\n\r\n"},
+		{"string", "GET String SHIORI/2.6\r\nString: homeurl\r\n\r\n", "GET SHIORI/3.0\r\nID: homeurl\r\n\r\n"},
+		{"owner ghost name", "NOTIFY OwnerGhostName SHIORI/2.6\r\nGhost: sakura\r\n\r\n", "NOTIFY SHIORI/3.0\r\nID: ownerghostname\r\nReference0: sakura\r\n\r\n"},
+		{"translate", "TRANSLATE Sentence SHIORI/2.6\r\nSentence: \\h\\e\r\n\r\n", "GET SHIORI/3.0\r\nID: OnTranslate\r\nReference0: \\h\\e\r\n\r\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v2 := MustParseRequest(test.v2)
+			v3 := MustParseRequest(test.v3)
+			if got, err := v2.ToV3(); err != nil || !reflect.DeepEqual(got, v3) {
+				t.Errorf("ToV3() = %q, %v, want %q", got.String(), err, v3.String())
+			}
+			if got, err := v3.ToV2(); err != nil || !reflect.DeepEqual(got, v2) {
+				t.Errorf("ToV2() = %q, %v, want %q", got.String(), err, v2.String())
+			}
+		})
+	}
+}
+
+func TestRequestConversionError(t *testing.T) {
+	request := MustParseRequest("TEACH SHIORI/2.4\r\nWord: hello\r\n\r\n")
+	if _, err := request.ToV3(); !errors.Is(err, ErrConvert) {
+		t.Errorf("ToV3() error = %v, want ErrConvert", err)
+	}
+}
+
+func TestResponseConversion(t *testing.T) {
+	v2 := MustParseResponse("SHIORI/2.6 200 OK\r\nCharset: UTF-8\r\nSentence: \\h\\e\r\n\r\n")
+	v3 := MustParseResponse("SHIORI/3.0 200 OK\r\nCharset: UTF-8\r\nValue: \\h\\e\r\n\r\n")
+	if got := v2.ToV3(); !reflect.DeepEqual(got, v3) {
+		t.Errorf("ToV3() = %q, want %q", got.String(), v3.String())
+	}
+	if got := v3.ToV2(); !reflect.DeepEqual(got, v2) {
+		t.Errorf("ToV2() = %q, want %q", got.String(), v2.String())
+	}
+}
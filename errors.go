@@ -0,0 +1,101 @@
+package shiori
+
+import (
+	"context"
+	"errors"
+)
+
+// Error taxonomy
+//
+// Every error type defined by this module matches one of the sentinels below with errors.Is.
+// Errors of the standard library, such as I/O and JSON errors of loaders, are returned as is or wrapped with %w.
+//
+//	ErrParse          malformed message (ParseRequestError, ParseResponseError, ParseHeaderError, InvalidMethodError,
+//	                  and their sstp and saori counterparts)
+//	ErrInvalidMethod  unknown request method (InvalidMethodError, sstp.InvalidMethodError, saori.InvalidMethodError)
+//	ErrCharset        unknown charset or undecodable/unencodable message (CharsetError)
+//	ErrConvert        message without counterpart in the other SHIORI version (ConvertError)
+//	ErrTemplate       broken response template or failure executing it (TemplateError)
+//	ErrGuard          response rejected by ResponseGuard (ResponseGuardError, reported in ErrorDescription)
+//	events.ErrDecode      request does not match the event layout (events.DecodeError, events.SchemaError)
+//	events.ErrUnsafePath  dropped path rejected by events.PathPolicy (events.UnsafePathError)
+//	export.ErrExport      failure in the DLL entry points (export.ExportError)
+//
+// The sentinels and the concrete error types are stable API; the text of error messages is not
+// and must not be matched on.
+var (
+	// ErrParse matches errors of malformed SHIORI messages
+	ErrParse = errors.New("shiori: parse error")
+	// ErrInvalidMethod matches errors of unknown request methods
+	ErrInvalidMethod = errors.New("shiori: invalid method")
+	// ErrCharset matches errors of message charset conversion
+	ErrCharset = errors.New("shiori: charset error")
+	// ErrConvert matches errors of SHIORI version conversion
+	ErrConvert = errors.New("shiori: convert error")
+	// ErrTemplate matches errors of response templates
+	ErrTemplate = errors.New("shiori: template error")
+	// ErrGuard matches errors of responses rejected by ResponseGuard
+	ErrGuard = errors.New("shiori: response guard error")
+)
+
+// Is reports InvalidMethodError matches ErrInvalidMethod and ErrParse
+func (err InvalidMethodError) Is(target error) bool {
+	return target == ErrInvalidMethod || target == ErrParse
+}
+
+// Is reports ParseRequestError matches ErrParse
+func (err ParseRequestError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// Is reports ParseResponseError matches ErrParse
+func (err ParseResponseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// Is reports ParseHeaderError matches ErrParse
+func (err ParseHeaderError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// Is reports CharsetError matches ErrCharset
+func (err CharsetError) Is(target error) bool {
+	return target == ErrCharset
+}
+
+// Is reports ConvertError matches ErrConvert
+func (err ConvertError) Is(target error) bool {
+	return target == ErrConvert
+}
+
+// Is reports TemplateError matches ErrTemplate
+func (err TemplateError) Is(target error) bool {
+	return target == ErrTemplate
+}
+
+// Is reports ResponseGuardError matches ErrGuard
+func (err ResponseGuardError) Is(target error) bool {
+	return target == ErrGuard
+}
+
+// IsTemporary reports whether retrying the failed operation may succeed.
+//
+// Parse errors are permanent. Errors in the chain reporting Temporary() or Timeout()
+// (such as net.Error) and context.DeadlineExceeded are temporary.
+func IsTemporary(err error) bool {
+	if err == nil || errors.Is(err, ErrParse) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	return false
+}
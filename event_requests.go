@@ -0,0 +1,119 @@
+package shiori
+
+import "strconv"
+
+// NewRequest makes SHIORI/3.0 Request with ID and Reference* headers
+func NewRequest(method Method, id string, references ...string) Request {
+	headers := make(RequestHeaders, len(references)+1)
+	headers[HeaderID] = id
+	for i, reference := range references {
+		headers[HeaderReferencePrefix+strconv.Itoa(i)] = reference
+	}
+	return Request{
+		Method:   method,
+		Protocol: SHIORI,
+		Version:  DefaultVersion,
+		Headers:  headers,
+	}
+}
+
+func boolReference(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// NewOnBootRequest makes GET OnBoot Request
+//
+// Reference0: shell name
+func NewOnBootRequest(shellName string) Request {
+	return NewRequest(GET, "OnBoot", shellName)
+}
+
+// NewOnCloseRequest makes GET OnClose Request
+//
+// Reference0: reason ("user" or "system")
+func NewOnCloseRequest(reason string) Request {
+	return NewRequest(GET, "OnClose", reason)
+}
+
+// NewOnSecondChangeRequest makes GET OnSecondChange Request
+//
+// Reference0: OS uptime in hours, Reference1: mikire (partially off screen), Reference2: kasanari (characters overlapped),
+// Reference3: can talk, Reference4: idle seconds
+func NewOnSecondChangeRequest(uptime int, mikire bool, kasanari bool, canTalk bool, idle int) Request {
+	return NewRequest(GET, "OnSecondChange", timeChangeReferences(uptime, mikire, kasanari, canTalk, idle)...)
+}
+
+// NewOnMinuteChangeRequest makes GET OnMinuteChange Request (same references as OnSecondChange)
+func NewOnMinuteChangeRequest(uptime int, mikire bool, kasanari bool, canTalk bool, idle int) Request {
+	return NewRequest(GET, "OnMinuteChange", timeChangeReferences(uptime, mikire, kasanari, canTalk, idle)...)
+}
+
+func timeChangeReferences(uptime int, mikire bool, kasanari bool, canTalk bool, idle int) []string {
+	return []string{
+		strconv.Itoa(uptime),
+		boolReference(mikire),
+		boolReference(kasanari),
+		boolReference(canTalk),
+		strconv.Itoa(idle),
+	}
+}
+
+// MouseButton is Reference5 of mouse click events
+type MouseButton int
+
+const (
+	// MouseButtonLeft is left button
+	MouseButtonLeft MouseButton = iota
+	// MouseButtonRight is right button
+	MouseButtonRight
+	// MouseButtonMiddle is middle (wheel) button
+	MouseButtonMiddle
+)
+
+// NewOnMouseClickRequest makes GET OnMouseClick Request
+//
+// Reference0: x, Reference1: y, Reference2: wheel (always 0), Reference3: character scope,
+// Reference4: collision part, Reference5: button
+func NewOnMouseClickRequest(x int, y int, scope int, part string, button MouseButton) Request {
+	return NewRequest(GET, "OnMouseClick", mouseReferences(x, y, 0, scope, part, button)...)
+}
+
+// NewOnMouseDoubleClickRequest makes GET OnMouseDoubleClick Request (same references as OnMouseClick)
+func NewOnMouseDoubleClickRequest(x int, y int, scope int, part string, button MouseButton) Request {
+	return NewRequest(GET, "OnMouseDoubleClick", mouseReferences(x, y, 0, scope, part, button)...)
+}
+
+// NewOnMouseMoveRequest makes GET OnMouseMove Request
+//
+// Reference0: x, Reference1: y, Reference2: wheel (always 0), Reference3: character scope, Reference4: collision part
+func NewOnMouseMoveRequest(x int, y int, scope int, part string) Request {
+	return NewRequest(GET, "OnMouseMove", mouseReferences(x, y, 0, scope, part, MouseButtonLeft)[:5]...)
+}
+
+// NewOnMouseWheelRequest makes GET OnMouseWheel Request
+//
+// Reference0: x, Reference1: y, Reference2: wheel rotation, Reference3: character scope, Reference4: collision part
+func NewOnMouseWheelRequest(x int, y int, wheel int, scope int, part string) Request {
+	return NewRequest(GET, "OnMouseWheel", mouseReferences(x, y, wheel, scope, part, MouseButtonLeft)[:5]...)
+}
+
+func mouseReferences(x int, y int, wheel int, scope int, part string, button MouseButton) []string {
+	return []string{
+		strconv.Itoa(x),
+		strconv.Itoa(y),
+		strconv.Itoa(wheel),
+		strconv.Itoa(scope),
+		part,
+		strconv.Itoa(int(button)),
+	}
+}
+
+// NewOnChoiceSelectRequest makes GET OnChoiceSelect Request
+//
+// Reference0: selected choice ID
+func NewOnChoiceSelectRequest(choiceID string) Request {
+	return NewRequest(GET, "OnChoiceSelect", choiceID)
+}
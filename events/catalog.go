@@ -0,0 +1,28 @@
+package events
+
+import shiori "github.com/Narazaka/shiorigo"
+
+// CatalogSchemas makes Schemas of all events in the shiori event catalog.
+// References are not required since basewares differ in which ones they send.
+func CatalogSchemas() Schemas {
+	schemas := Schemas{}
+	for _, event := range shiori.Events() {
+		schema := make(ReferenceSchema, 0, len(event.References))
+		for _, reference := range event.References {
+			schema = append(schema, ReferenceField{Index: reference.Index, Type: catalogReferenceType(reference.Type)})
+		}
+		schemas.Register(event.ID, schema)
+	}
+	return schemas
+}
+
+func catalogReferenceType(name string) ReferenceType {
+	switch name {
+	case "int":
+		return IntReference
+	case "bool":
+		return BoolReference
+	default:
+		return StringReference
+	}
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// listSeparator (byte 1) separates list items such as dropped paths in Reference0 of OnFileDrop2
+const listSeparator = "\x01"
+
+// OnFileDrop2 is raised when files are dropped on a character
+type OnFileDrop2 struct {
+	// Paths are the dropped files (Reference0, byte-1 separated)
+	Paths []string
+	// Scope is the character the files were dropped on (Reference1)
+	Scope int
+}
+
+// DecodeOnFileDrop2 decodes OnFileDrop2 request
+func DecodeOnFileDrop2(request shiori.Request) (OnFileDrop2, error) {
+	if err := checkID(request, "OnFileDrop2"); err != nil {
+		return OnFileDrop2{}, err
+	}
+	var paths []string
+	if reference := request.Reference(0); reference != "" {
+		paths = strings.Split(reference, listSeparator)
+	}
+	scope, err := scopeReference(request, 1)
+	if err != nil {
+		return OnFileDrop2{}, err
+	}
+	return OnFileDrop2{Paths: paths, Scope: scope}, nil
+}
+
+// OnDirectoryDrop is raised when a directory is dropped on a character
+type OnDirectoryDrop struct {
+	// Path is the dropped directory (Reference0)
+	Path string
+	// Scope is the character the directory was dropped on (Reference1)
+	Scope int
+}
+
+// DecodeOnDirectoryDrop decodes OnDirectoryDrop request
+func DecodeOnDirectoryDrop(request shiori.Request) (OnDirectoryDrop, error) {
+	if err := checkID(request, "OnDirectoryDrop"); err != nil {
+		return OnDirectoryDrop{}, err
+	}
+	scope, err := scopeReference(request, 1)
+	if err != nil {
+		return OnDirectoryDrop{}, err
+	}
+	return OnDirectoryDrop{Path: request.Reference(0), Scope: scope}, nil
+}
+
+// OnURLDropping is raised when a URL is dropped, before its download begins
+type OnURLDropping struct {
+	// URL is the dropped URL (Reference0)
+	URL string
+}
+
+// DecodeOnURLDropping decodes OnURLDropping request
+func DecodeOnURLDropping(request shiori.Request) (OnURLDropping, error) {
+	if err := checkID(request, "OnURLDropping"); err != nil {
+		return OnURLDropping{}, err
+	}
+	return OnURLDropping{URL: request.Reference(0)}, nil
+}
+
+// scopeReference parses optional character scope reference (0 when absent)
+func scopeReference(request shiori.Request, index int) (int, error) {
+	if _, ok := request.ReferenceOK(index); !ok {
+		return 0, nil
+	}
+	return intReference(request, index)
+}
+
+// UnsafePathError is error of a path rejected by PathPolicy
+type UnsafePathError string
+
+func (err UnsafePathError) Error() string {
+	return "UnsafePathError: " + string(err)
+}
+
+// ErrUnsafePath matches UnsafePathError with errors.Is
+var ErrUnsafePath = errors.New("events: unsafe path")
+
+// Is reports UnsafePathError matches ErrUnsafePath
+func (err UnsafePathError) Is(target error) bool {
+	return target == ErrUnsafePath
+}
+
+// PathPolicy validates dropped paths before they are used
+type PathPolicy struct {
+	// Roots restrict paths to be under one of them (any path when empty)
+	Roots []string
+	// Extensions restrict file extensions, e.g. ".txt" (any extension when empty; compared case-insensitively)
+	Extensions []string
+}
+
+// Sanitize cleans the path and checks it against the policy
+func (policy PathPolicy) Sanitize(path string) (string, error) {
+	if path == "" {
+		return "", UnsafePathError("empty path")
+	}
+	for _, c := range path {
+		if c < 0x20 || c == 0x7f {
+			return "", UnsafePathError("path contains control character: " + strconv.Quote(path))
+		}
+	}
+	if !filepath.IsAbs(path) {
+		return "", UnsafePathError("path is not absolute: " + path)
+	}
+	cleaned := filepath.Clean(path)
+	if len(policy.Roots) != 0 && !policy.underRoot(cleaned) {
+		return "", UnsafePathError("path is outside of allowed roots: " + cleaned)
+	}
+	if len(policy.Extensions) != 0 && !policy.allowedExtension(cleaned) {
+		return "", UnsafePathError("path has disallowed extension: " + cleaned)
+	}
+	return cleaned, nil
+}
+
+func (policy PathPolicy) underRoot(path string) bool {
+	for _, root := range policy.Roots {
+		relative, err := filepath.Rel(filepath.Clean(root), path)
+		if err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (policy PathPolicy) allowedExtension(path string) bool {
+	extension := filepath.Ext(path)
+	for _, allowed := range policy.Extensions {
+		if strings.EqualFold(extension, allowed) {
+			return true
+		}
+	}
+	return false
+}
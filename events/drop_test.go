@@ -0,0 +1,72 @@
+package events
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestDecodeOnFileDrop2(t *testing.T) {
+	tests := []struct {
+		name    string
+		request shiori.Request
+		want    OnFileDrop2
+		wantErr error
+	}{
+		{"one path", shiori.NewRequest(shiori.NOTIFY, "OnFileDrop2", `C:\a.txt`), OnFileDrop2{Paths: []string{`C:\a.txt`}}, nil},
+		{"byte-1 separated paths", shiori.NewRequest(shiori.NOTIFY, "OnFileDrop2", "C:\\a.txt\x01C:\\b c.txt\x01C:\\表.txt", "1"), OnFileDrop2{Paths: []string{`C:\a.txt`, `C:\b c.txt`, `C:\表.txt`}, Scope: 1}, nil},
+		{"empty", shiori.NewRequest(shiori.NOTIFY, "OnFileDrop2", ""), OnFileDrop2{}, nil},
+		{"broken scope", shiori.NewRequest(shiori.NOTIFY, "OnFileDrop2", `C:\a.txt`, "x"), OnFileDrop2{}, ErrDecode},
+		{"other event", shiori.NewRequest(shiori.NOTIFY, "OnDirectoryDrop", `C:\a`), OnFileDrop2{}, ErrDecode},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event, err := DecodeOnFileDrop2(test.request)
+			if !errors.Is(err, test.wantErr) || (err != nil) != (test.wantErr != nil) {
+				t.Fatalf("DecodeOnFileDrop2() error = %v, want %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(event, test.want) {
+				t.Errorf("DecodeOnFileDrop2() = %+v, want %+v", event, test.want)
+			}
+		})
+	}
+}
+
+func TestPathPolicySanitize(t *testing.T) {
+	root := t.TempDir()
+	policy := PathPolicy{Roots: []string{root}, Extensions: []string{".txt"}}
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"under root", filepath.Join(root, "a", "b.txt"), filepath.Join(root, "a", "b.txt"), false},
+		{"extension case", filepath.Join(root, "B.TXT"), filepath.Join(root, "B.TXT"), false},
+		{"cleaned", root + string(filepath.Separator) + "a" + string(filepath.Separator) + ".." + string(filepath.Separator) + "b.txt", filepath.Join(root, "b.txt"), false},
+		{"escapes root", filepath.Join(root, "..", "b.txt"), "", true},
+		{"escapes root after clean", root + string(filepath.Separator) + ".." + string(filepath.Separator) + filepath.Base(root) + "2" + string(filepath.Separator) + "b.txt", "", true},
+		{"sibling with root prefix", root + "2" + string(filepath.Separator) + "b.txt", "", true},
+		{"disallowed extension", filepath.Join(root, "b.exe"), "", true},
+		{"relative", filepath.Join("a", "b.txt"), "", true},
+		{"control character", filepath.Join(root, "a\x00.txt"), "", true},
+		{"empty", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := policy.Sanitize(test.path)
+			if (err != nil) != test.wantErr || (err != nil && !errors.Is(err, ErrUnsafePath)) {
+				t.Fatalf("Sanitize(%q) error = %v, want error %v", test.path, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", test.path, got, test.want)
+			}
+		})
+	}
+	if got, err := (PathPolicy{}).Sanitize(filepath.Join(root, "b.exe")); err != nil || got != filepath.Join(root, "b.exe") {
+		t.Errorf("Sanitize() without restrictions = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,45 @@
+// Package events decodes SHIORI requests of standard events into typed structs.
+package events
+
+import (
+	"errors"
+	"strconv"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// DecodeError is event decoding error
+type DecodeError string
+
+func (err DecodeError) Error() string {
+	return "DecodeError: " + string(err)
+}
+
+// ErrDecode matches DecodeError with errors.Is
+var ErrDecode = errors.New("events: decode error")
+
+// Is reports DecodeError matches ErrDecode
+func (err DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// checkID returns DecodeError if the request is not the event
+func checkID(request shiori.Request, ids ...string) error {
+	id := request.EventID()
+	for _, expected := range ids {
+		if id == expected {
+			return nil
+		}
+	}
+	return DecodeError("unexpected event ID: " + id)
+}
+
+// intReference parses Reference(index) as int
+func intReference(request shiori.Request, index int) (int, error) {
+	value := request.Reference(index)
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, DecodeError(request.EventID() + " Reference" + strconv.Itoa(index) + " expected int, got '" + value + "'")
+	}
+	return number, nil
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestDecodeV2Request(t *testing.T) {
+	request := shiori.MustParseRequest("GET Sentence SHIORI/2.2\r\nEvent: OnKeyPress\r\nReference0: a\r\nReference1: 65\r\n\r\n")
+	event, err := DecodeOnKeyPress(request)
+	if err != nil || event.Key != "a" || event.Code != 65 {
+		t.Errorf("DecodeOnKeyPress() = %+v, %v, want a 65", event, err)
+	}
+	request.Headers["Reference1"] = "x"
+	if _, err := DecodeOnKeyPress(request); !errors.Is(err, ErrDecode) || !strings.Contains(err.Error(), "OnKeyPress Reference1") {
+		t.Errorf("DecodeOnKeyPress() error = %v, want one naming OnKeyPress Reference1", err)
+	}
+	schemas := Schemas{}
+	schemas.Register("OnKeyPress", ReferenceSchema{{Index: 1, Type: IntReference, Required: true}})
+	if err := schemas.Validate(request); err == nil || !strings.Contains(err.Error(), "OnKeyPress Reference1") {
+		t.Errorf("Validate() error = %v, want one naming OnKeyPress Reference1", err)
+	}
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"strconv"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// The helpers below are shared by decoders generated by shiori-eventgen, so that generated files never collide.
+// Absent references decode to zero values and errors are DecodeError.
+
+// CheckID returns DecodeError if the request is not one of the events
+func CheckID(request shiori.Request, ids ...string) error {
+	return checkID(request, ids...)
+}
+
+// ParseIntReference parses Reference(index) as int (0 when absent)
+func ParseIntReference(request shiori.Request, index int) (int, error) {
+	if _, ok := request.ReferenceOK(index); !ok {
+		return 0, nil
+	}
+	return intReference(request, index)
+}
+
+// ParseBoolReference parses Reference(index) "0" or "1" as bool (false when absent)
+func ParseBoolReference(request shiori.Request, index int) (bool, error) {
+	value, ok := request.ReferenceOK(index)
+	if !ok {
+		return false, nil
+	}
+	switch value {
+	case "0":
+		return false, nil
+	case "1":
+		return true, nil
+	}
+	return false, DecodeError(request.EventID() + " Reference" + strconv.Itoa(index) + " expected bool, got '" + value + "'")
+}
+
+// SplitListReference splits byte-1 separated Reference(index) (nil when empty)
+func SplitListReference(request shiori.Request, index int) []string {
+	return splitList(request.Reference(index))
+}
+
+// FormatBoolReference formats the bool as Reference* value "0" or "1"
+func FormatBoolReference(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,63 @@
+package events
+
+import shiori "github.com/Narazaka/shiorigo"
+
+// GhostInfo is the reference layout shared by ghost switching events
+type GhostInfo struct {
+	// SakuraName is \0 name of the ghost (Reference0)
+	SakuraName string
+	// Script is the last script the ghost talked (Reference1)
+	Script string
+	// GhostName is name of the ghost (Reference2)
+	GhostName string
+	// Path is the ghost directory (Reference3)
+	Path string
+}
+
+func decodeGhostInfo(request shiori.Request) GhostInfo {
+	return GhostInfo{
+		SakuraName: request.Reference(0),
+		Script:     request.Reference(1),
+		GhostName:  request.Reference(2),
+		Path:       request.Reference(3),
+	}
+}
+
+// OnGhostChanged is raised after switching from another ghost; GhostInfo is the previous ghost
+type OnGhostChanged struct {
+	GhostInfo
+}
+
+// DecodeOnGhostChanged decodes OnGhostChanged request
+func DecodeOnGhostChanged(request shiori.Request) (OnGhostChanged, error) {
+	if err := checkID(request, "OnGhostChanged"); err != nil {
+		return OnGhostChanged{}, err
+	}
+	return OnGhostChanged{decodeGhostInfo(request)}, nil
+}
+
+// OnGhostCalled is raised when called by \![call,ghost,...]; GhostInfo is the caller
+type OnGhostCalled struct {
+	GhostInfo
+}
+
+// DecodeOnGhostCalled decodes OnGhostCalled request
+func DecodeOnGhostCalled(request shiori.Request) (OnGhostCalled, error) {
+	if err := checkID(request, "OnGhostCalled"); err != nil {
+		return OnGhostCalled{}, err
+	}
+	return OnGhostCalled{decodeGhostInfo(request)}, nil
+}
+
+// OnOtherGhostBooted is raised when another ghost boots; GhostInfo is the booted ghost
+type OnOtherGhostBooted struct {
+	GhostInfo
+}
+
+// DecodeOnOtherGhostBooted decodes OnOtherGhostBooted request
+func DecodeOnOtherGhostBooted(request shiori.Request) (OnOtherGhostBooted, error) {
+	if err := checkID(request, "OnOtherGhostBooted"); err != nil {
+		return OnOtherGhostBooted{}, err
+	}
+	return OnOtherGhostBooted{decodeGhostInfo(request)}, nil
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// OnInstallBegin is raised when installation of a dropped .nar begins
+type OnInstallBegin struct{}
+
+// DecodeOnInstallBegin decodes OnInstallBegin request
+func DecodeOnInstallBegin(request shiori.Request) (OnInstallBegin, error) {
+	if err := checkID(request, "OnInstallBegin"); err != nil {
+		return OnInstallBegin{}, err
+	}
+	return OnInstallBegin{}, nil
+}
+
+// OnInstallComplete is raised when installation succeeded
+type OnInstallComplete struct {
+	// Types are installed types such as "ghost", "shell", "balloon" or "plugin" (Reference0, comma separated when bundled)
+	Types []string
+	// Name is name of the installed item (Reference1)
+	Name string
+	// SecondName is name of the bundled item such as a balloon in a ghost archive (Reference2)
+	SecondName string
+}
+
+// DecodeOnInstallComplete decodes OnInstallComplete request
+func DecodeOnInstallComplete(request shiori.Request) (OnInstallComplete, error) {
+	if err := checkID(request, "OnInstallComplete"); err != nil {
+		return OnInstallComplete{}, err
+	}
+	var types []string
+	if reference := request.Reference(0); reference != "" {
+		types = strings.Split(reference, ",")
+	}
+	return OnInstallComplete{
+		Types:      types,
+		Name:       request.Reference(1),
+		SecondName: request.Reference(2),
+	}, nil
+}
+
+// Includes reports whether the type was installed
+func (event OnInstallComplete) Includes(installedType string) bool {
+	for _, t := range event.Types {
+		if t == installedType {
+			return true
+		}
+	}
+	return false
+}
+
+var installTypeNames = map[string]string{
+	"ghost":      "ゴースト",
+	"shell":      "シェル",
+	"balloon":    "バルーン",
+	"plugin":     "プラグイン",
+	"headline":   "ヘッドライン",
+	"supplement": "追加ファイル",
+}
+
+// Narration describes what was installed in Japanese, e.g. ゴースト「名前」とバルーン「名前」
+func (event OnInstallComplete) Narration() string {
+	names := []string{event.Name, event.SecondName}
+	parts := make([]string, 0, len(event.Types))
+	for i, t := range event.Types {
+		label, ok := installTypeNames[t]
+		if !ok {
+			label = t
+		}
+		if i < len(names) && names[i] != "" {
+			label += "「" + names[i] + "」"
+		}
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, "と")
+}
+
+// OnInstallFailure is raised when installation failed
+type OnInstallFailure struct {
+	// Reason is failure reason such as "extraction", "invalid type" or "artificial" (Reference0)
+	Reason string
+}
+
+// DecodeOnInstallFailure decodes OnInstallFailure request
+func DecodeOnInstallFailure(request shiori.Request) (OnInstallFailure, error) {
+	if err := checkID(request, "OnInstallFailure"); err != nil {
+		return OnInstallFailure{}, err
+	}
+	return OnInstallFailure{Reason: request.Reference(0)}, nil
+}
+
+// OnInstallRefuse is raised when the archive is addressed to another ghost (accept field)
+type OnInstallRefuse struct {
+	// GhostName is the ghost which the archive is addressed to (Reference0)
+	GhostName string
+}
+
+// DecodeOnInstallRefuse decodes OnInstallRefuse request
+func DecodeOnInstallRefuse(request shiori.Request) (OnInstallRefuse, error) {
+	if err := checkID(request, "OnInstallRefuse"); err != nil {
+		return OnInstallRefuse{}, err
+	}
+	return OnInstallRefuse{GhostName: request.Reference(0)}, nil
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestDecodeOnInstallComplete(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       shiori.Request
+		want          OnInstallComplete
+		wantNarration string
+	}{
+		{
+			"ghost with balloon",
+			shiori.NewRequest(shiori.NOTIFY, "OnInstallComplete", "ghost,balloon", "さくら", "さくらバルーン"),
+			OnInstallComplete{Types: []string{"ghost", "balloon"}, Name: "さくら", SecondName: "さくらバルーン"},
+			"ゴースト「さくら」とバルーン「さくらバルーン」",
+		},
+		{
+			"shell",
+			shiori.NewRequest(shiori.NOTIFY, "OnInstallComplete", "shell", "冬服"),
+			OnInstallComplete{Types: []string{"shell"}, Name: "冬服"},
+			"シェル「冬服」",
+		},
+		{
+			"unknown type without name",
+			shiori.NewRequest(shiori.NOTIFY, "OnInstallComplete", "calendar skin"),
+			OnInstallComplete{Types: []string{"calendar skin"}},
+			"calendar skin",
+		},
+		{
+			"no references",
+			shiori.NewRequest(shiori.NOTIFY, "OnInstallComplete"),
+			OnInstallComplete{},
+			"",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event, err := DecodeOnInstallComplete(test.request)
+			if err != nil {
+				t.Fatalf("DecodeOnInstallComplete() error = %v", err)
+			}
+			if !reflect.DeepEqual(event, test.want) {
+				t.Errorf("DecodeOnInstallComplete() = %+v, want %+v", event, test.want)
+			}
+			if narration := event.Narration(); narration != test.wantNarration {
+				t.Errorf("Narration() = %q, want %q", narration, test.wantNarration)
+			}
+		})
+	}
+	if _, err := DecodeOnInstallComplete(shiori.NewRequest(shiori.NOTIFY, "OnInstallFailure")); !errors.Is(err, ErrDecode) {
+		t.Errorf("DecodeOnInstallComplete() of other event error = %v, want ErrDecode", err)
+	}
+}
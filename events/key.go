@@ -0,0 +1,65 @@
+package events
+
+import (
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// OnKeyPress is raised when a key is pressed while a character is focused
+type OnKeyPress struct {
+	// Key is key name such as "a", "f1" or "up" (Reference0)
+	Key string
+	// Code is key code (Reference1)
+	Code int
+	// Repeat is repeat count of the key (Reference2, 0 when absent)
+	Repeat int
+}
+
+// DecodeOnKeyPress decodes OnKeyPress request
+func DecodeOnKeyPress(request shiori.Request) (OnKeyPress, error) {
+	if err := checkID(request, "OnKeyPress"); err != nil {
+		return OnKeyPress{}, err
+	}
+	code, err := intReference(request, 1)
+	if err != nil {
+		return OnKeyPress{}, err
+	}
+	repeat := 0
+	if _, ok := request.ReferenceOK(2); ok {
+		repeat, err = intReference(request, 2)
+		if err != nil {
+			return OnKeyPress{}, err
+		}
+	}
+	return OnKeyPress{Key: request.Reference(0), Code: code, Repeat: repeat}, nil
+}
+
+// KeyBindings maps key names (case-insensitive) to callbacks for OnKeyPress
+type KeyBindings struct {
+	bindings map[string]func(event OnKeyPress, request shiori.Request) shiori.Response
+}
+
+// Bind registers the callback for the key
+func (keyBindings *KeyBindings) Bind(key string, callback func(event OnKeyPress, request shiori.Request) shiori.Response) {
+	if keyBindings.bindings == nil {
+		keyBindings.bindings = map[string]func(event OnKeyPress, request shiori.Request) shiori.Response{}
+	}
+	keyBindings.bindings[strings.ToLower(key)] = callback
+}
+
+// Dispatch calls the callback bound to the pressed key; ok is false for other requests or unbound keys
+func (keyBindings *KeyBindings) Dispatch(request shiori.Request) (response shiori.Response, ok bool) {
+	if request.EventID() != "OnKeyPress" {
+		return response, false
+	}
+	event, err := DecodeOnKeyPress(request)
+	if err != nil {
+		return shiori.BadRequest(err.Error(), shiori.WithDefaultsFrom(request)), true
+	}
+	callback, ok := keyBindings.bindings[strings.ToLower(event.Key)]
+	if !ok {
+		return response, false
+	}
+	return callback(event, request), true
+}
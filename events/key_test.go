@@ -0,0 +1,41 @@
+package events
+
+import (
+	"strconv"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestKeyBindingsDispatch(t *testing.T) {
+	var keyBindings KeyBindings
+	keyBindings.Bind("F1", func(event OnKeyPress, request shiori.Request) shiori.Response {
+		return shiori.OK("help:"+strconv.Itoa(event.Repeat), shiori.WithDefaultsFrom(request))
+	})
+	tests := []struct {
+		name      string
+		request   shiori.Request
+		wantOK    bool
+		wantCode  int
+		wantValue string
+	}{
+		{"bound key", shiori.NewRequest(shiori.GET, "OnKeyPress", "f1", "112"), true, 200, "help:0"},
+		{"case-insensitive with repeat", shiori.NewRequest(shiori.GET, "OnKeyPress", "F1", "112", "3"), true, 200, "help:3"},
+		{"unbound key", shiori.NewRequest(shiori.GET, "OnKeyPress", "a", "65"), false, 0, ""},
+		{"broken code", shiori.NewRequest(shiori.GET, "OnKeyPress", "f1", "x"), true, 400, ""},
+		{"broken repeat", shiori.NewRequest(shiori.GET, "OnKeyPress", "f1", "112", "x"), true, 400, ""},
+		{"other event", shiori.NewRequest(shiori.GET, "OnBoot", "f1", "112"), false, 0, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, ok := keyBindings.Dispatch(test.request)
+			if ok != test.wantOK || response.Code != test.wantCode || response.Value(0) != test.wantValue {
+				t.Errorf("Dispatch() = %q, %v, want %d %q, %v", response.String(), ok, test.wantCode, test.wantValue, test.wantOK)
+			}
+		})
+	}
+	var empty KeyBindings
+	if _, ok := empty.Dispatch(shiori.NewRequest(shiori.GET, "OnKeyPress", "f1", "112")); ok {
+		t.Error("Dispatch() of zero KeyBindings found a binding")
+	}
+}
@@ -0,0 +1,223 @@
+package events
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// NotifyInfoIDs are IDs of NOTIFY requests informing the ghost about the baseware environment
+var NotifyInfoIDs = []string{
+	"basewareversion", "hwnd", "uniqueid", "capability",
+	"ownerghostname", "otherghostname",
+	"installedsakuraname", "installedkeroname", "installedghostname",
+	"installedshellname", "installedballoonname", "installedheadlinename", "installedplugin",
+	"configuredbiffname", "ghostpathlist", "balloonpathlist", "headlinepathlist", "pluginpathlist",
+	"rateofusegraph", "rateofusegraphballoon", "rateofusegraphtotal",
+}
+
+func isNotifyInfoID(id string) bool {
+	for _, infoID := range NotifyInfoIDs {
+		if id == infoID {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits byte-1 separated list (nil for empty)
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, listSeparator)
+}
+
+// orderedReferences returns Reference* values ordered by index
+func orderedReferences(request shiori.Request) []string {
+	references := request.References()
+	indexes := make([]int, 0, len(references))
+	for index := range references {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	values := make([]string, len(indexes))
+	for i, index := range indexes {
+		values[i] = references[index]
+	}
+	return values
+}
+
+// BasewareVersion is basewareversion notification
+type BasewareVersion struct {
+	// Name is baseware name such as "SSP" (Reference0)
+	Name string
+	// Version is baseware version (Reference1)
+	Version string
+}
+
+// DecodeBasewareVersion decodes basewareversion request
+func DecodeBasewareVersion(request shiori.Request) (BasewareVersion, error) {
+	if err := checkID(request, "basewareversion"); err != nil {
+		return BasewareVersion{}, err
+	}
+	return BasewareVersion{Name: request.Reference(0), Version: request.Reference(1)}, nil
+}
+
+// OtherGhost is one running ghost in otherghostname notification
+type OtherGhost struct {
+	SakuraName    string
+	SakuraSurface string
+	KeroSurface   string
+}
+
+// DecodeOtherGhostName decodes otherghostname request (one ghost per Reference*, fields byte-1 separated)
+func DecodeOtherGhostName(request shiori.Request) ([]OtherGhost, error) {
+	if err := checkID(request, "otherghostname"); err != nil {
+		return nil, err
+	}
+	var ghosts []OtherGhost
+	for _, reference := range orderedReferences(request) {
+		fields := append(splitList(reference), "", "", "")
+		ghosts = append(ghosts, OtherGhost{SakuraName: fields[0], SakuraSurface: fields[1], KeroSurface: fields[2]})
+	}
+	return ghosts, nil
+}
+
+// RateOfUse is one ghost in rateofusegraph notification
+type RateOfUse struct {
+	GhostName  string
+	SakuraName string
+	KeroName   string
+	BootCount  int
+	// Minutes is total running time in minutes
+	Minutes int
+	// Percentage is share of running time
+	Percentage float64
+	// Status is "running" or empty
+	Status string
+}
+
+// DecodeRateOfUseGraph decodes rateofusegraph request (one ghost per Reference*, fields byte-1 separated)
+func DecodeRateOfUseGraph(request shiori.Request) ([]RateOfUse, error) {
+	if err := checkID(request, "rateofusegraph"); err != nil {
+		return nil, err
+	}
+	var rates []RateOfUse
+	for _, reference := range orderedReferences(request) {
+		fields := append(splitList(reference), "", "", "", "", "", "", "")
+		bootCount, _ := strconv.Atoi(fields[3])
+		minutes, _ := strconv.Atoi(fields[4])
+		percentage, _ := strconv.ParseFloat(fields[5], 64)
+		rates = append(rates, RateOfUse{
+			GhostName:  fields[0],
+			SakuraName: fields[1],
+			KeroName:   fields[2],
+			BootCount:  bootCount,
+			Minutes:    minutes,
+			Percentage: percentage,
+			Status:     fields[6],
+		})
+	}
+	return rates, nil
+}
+
+// Environment stores baseware environment informed by NOTIFY requests; it is safe for concurrent use
+type Environment struct {
+	mutex      sync.RWMutex
+	references map[string]map[int]string
+}
+
+// Observe records the request if it is an environment notification and returns whether it was
+func (environment *Environment) Observe(request shiori.Request) bool {
+	id := request.EventID()
+	if request.Method != shiori.NOTIFY || !isNotifyInfoID(id) {
+		return false
+	}
+	environment.mutex.Lock()
+	defer environment.mutex.Unlock()
+	if environment.references == nil {
+		environment.references = map[string]map[int]string{}
+	}
+	environment.references[id] = request.References()
+	return true
+}
+
+// request restores the last notification of the ID as Request
+func (environment *Environment) request(id string) (shiori.Request, bool) {
+	environment.mutex.RLock()
+	defer environment.mutex.RUnlock()
+	references, ok := environment.references[id]
+	if !ok {
+		return shiori.Request{}, false
+	}
+	request := shiori.NewRequest(shiori.NOTIFY, id)
+	for index, value := range references {
+		request.Headers[shiori.HeaderReferencePrefix+strconv.Itoa(index)] = value
+	}
+	return request, true
+}
+
+// Reference returns Reference(index) of the last notification of the ID
+func (environment *Environment) Reference(id string, index int) (string, bool) {
+	environment.mutex.RLock()
+	defer environment.mutex.RUnlock()
+	value, ok := environment.references[id][index]
+	return value, ok
+}
+
+// Baseware returns the last basewareversion notification
+func (environment *Environment) Baseware() (BasewareVersion, bool) {
+	request, ok := environment.request("basewareversion")
+	if !ok {
+		return BasewareVersion{}, false
+	}
+	baseware, _ := DecodeBasewareVersion(request)
+	return baseware, true
+}
+
+// OwnerGhostName returns sakura name of the ghost which owns this SHIORI (ownerghostname)
+func (environment *Environment) OwnerGhostName() (string, bool) {
+	return environment.Reference("ownerghostname", 0)
+}
+
+// OtherGhosts returns running ghosts other than this one (otherghostname)
+func (environment *Environment) OtherGhosts() []OtherGhost {
+	request, ok := environment.request("otherghostname")
+	if !ok {
+		return nil
+	}
+	ghosts, _ := DecodeOtherGhostName(request)
+	return ghosts
+}
+
+// InstalledGhostNames returns names of installed ghosts (installedghostname Reference0)
+func (environment *Environment) InstalledGhostNames() []string {
+	value, _ := environment.Reference("installedghostname", 0)
+	return splitList(value)
+}
+
+// InstalledShellNames returns names of installed shells of this ghost (installedshellname Reference0)
+func (environment *Environment) InstalledShellNames() []string {
+	value, _ := environment.Reference("installedshellname", 0)
+	return splitList(value)
+}
+
+// InstalledBalloonNames returns names of installed balloons (installedballoonname Reference0)
+func (environment *Environment) InstalledBalloonNames() []string {
+	value, _ := environment.Reference("installedballoonname", 0)
+	return splitList(value)
+}
+
+// RateOfUse returns usage statistics of ghosts (rateofusegraph)
+func (environment *Environment) RateOfUse() []RateOfUse {
+	request, ok := environment.request("rateofusegraph")
+	if !ok {
+		return nil
+	}
+	rates, _ := DecodeRateOfUseGraph(request)
+	return rates
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestEnvironmentObserve(t *testing.T) {
+	var environment Environment
+	if _, ok := environment.Baseware(); ok || environment.OtherGhosts() != nil {
+		t.Error("zero Environment has notifications")
+	}
+	tests := []struct {
+		name    string
+		request shiori.Request
+		want    bool
+	}{
+		{"basewareversion", shiori.NewRequest(shiori.NOTIFY, "basewareversion", "SSP", "2.6.92"), true},
+		{"ownerghostname", shiori.NewRequest(shiori.NOTIFY, "ownerghostname", "さくら"), true},
+		{"otherghostname", shiori.NewRequest(shiori.NOTIFY, "otherghostname", "まゆら\x010\x0110", "毒子\x015"), true},
+		{"installedghostname", shiori.NewRequest(shiori.NOTIFY, "installedghostname", "さくら\x01まゆら"), true},
+		{"rateofusegraph", shiori.NewRequest(shiori.NOTIFY, "rateofusegraph", "Emily\x01エミリ\x01テディ\x0112\x01345\x0167.5\x01running"), true},
+		{"GET of notification ID", shiori.NewRequest(shiori.GET, "ownerghostname", "偽物"), false},
+		{"other NOTIFY", shiori.NewRequest(shiori.NOTIFY, "OnBoot"), false},
+	}
+	for _, test := range tests {
+		if got := environment.Observe(test.request); got != test.want {
+			t.Errorf("Observe(%s) = %v, want %v", test.name, got, test.want)
+		}
+	}
+	if baseware, ok := environment.Baseware(); !ok || baseware != (BasewareVersion{Name: "SSP", Version: "2.6.92"}) {
+		t.Errorf("Baseware() = %+v, %v", baseware, ok)
+	}
+	if name, ok := environment.OwnerGhostName(); !ok || name != "さくら" {
+		t.Errorf("OwnerGhostName() = %q, %v, want さくら", name, ok)
+	}
+	wantGhosts := []OtherGhost{{SakuraName: "まゆら", SakuraSurface: "0", KeroSurface: "10"}, {SakuraName: "毒子", SakuraSurface: "5"}}
+	if ghosts := environment.OtherGhosts(); !reflect.DeepEqual(ghosts, wantGhosts) {
+		t.Errorf("OtherGhosts() = %+v, want %+v", ghosts, wantGhosts)
+	}
+	if names := environment.InstalledGhostNames(); !reflect.DeepEqual(names, []string{"さくら", "まゆら"}) {
+		t.Errorf("InstalledGhostNames() = %q", names)
+	}
+	if names := environment.InstalledShellNames(); names != nil {
+		t.Errorf("InstalledShellNames() = %q, want none", names)
+	}
+	wantRates := []RateOfUse{{GhostName: "Emily", SakuraName: "エミリ", KeroName: "テディ", BootCount: 12, Minutes: 345, Percentage: 67.5, Status: "running"}}
+	if rates := environment.RateOfUse(); !reflect.DeepEqual(rates, wantRates) {
+		t.Errorf("RateOfUse() = %+v, want %+v", rates, wantRates)
+	}
+	// the last notification replaces the previous one
+	environment.Observe(shiori.NewRequest(shiori.NOTIFY, "otherghostname"))
+	if ghosts := environment.OtherGhosts(); ghosts != nil {
+		t.Errorf("OtherGhosts() after empty notification = %+v, want none", ghosts)
+	}
+}
+
+func TestEnvironmentConcurrent(t *testing.T) {
+	var environment Environment
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(2)
+		go func() {
+			defer wait.Done()
+			environment.Observe(shiori.NewRequest(shiori.NOTIFY, "ownerghostname", "さくら"))
+		}()
+		go func() {
+			defer wait.Done()
+			environment.OwnerGhostName()
+		}()
+	}
+	wait.Wait()
+	if name, _ := environment.OwnerGhostName(); name != "さくら" {
+		t.Errorf("OwnerGhostName() = %q, want さくら", name)
+	}
+}
@@ -0,0 +1,167 @@
+package events
+
+import shiori "github.com/Narazaka/shiorigo"
+
+// Event is implemented by pointers to typed events so that On can register and decode them.
+// Types generated by shiori-eventgen implement it too.
+type Event[E any] interface {
+	*E
+	// EventID is ID of the event
+	EventID() string
+	// Decode sets the event decoded from the request
+	Decode(request shiori.Request) error
+}
+
+// DecodeErrorHandler answers requests which handlers registered by On cannot decode (400 Bad Request by default)
+var DecodeErrorHandler = func(request shiori.Request, err error) shiori.Response {
+	return shiori.BadRequest(err.Error(), shiori.WithDefaultsFrom(request))
+}
+
+// On registers the typed handler of the event E on the mux, e.g.
+//
+//	events.On(mux, func(event events.OnKeyPress, request shiori.Request) shiori.Response { ... })
+//
+// Requests are decoded into E before the handler is called; those failing to decode are answered by DecodeErrorHandler.
+// Events without handler are answered by the Fallback of the mux as usual.
+func On[E any, P Event[E]](mux *shiori.Mux, handler func(event E, request shiori.Request) shiori.Response) {
+	var zero E
+	mux.HandleFunc(P(&zero).EventID(), func(request shiori.Request) shiori.Response {
+		var event E
+		if err := P(&event).Decode(request); err != nil {
+			return DecodeErrorHandler(request, err)
+		}
+		return handler(event, request)
+	})
+}
+
+// EventID is "OnFileDrop2"
+func (*OnFileDrop2) EventID() string {
+	return "OnFileDrop2"
+}
+
+// Decode sets the event decoded by DecodeOnFileDrop2
+func (event *OnFileDrop2) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnFileDrop2(request)
+	return err
+}
+
+// EventID is "OnDirectoryDrop"
+func (*OnDirectoryDrop) EventID() string {
+	return "OnDirectoryDrop"
+}
+
+// Decode sets the event decoded by DecodeOnDirectoryDrop
+func (event *OnDirectoryDrop) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnDirectoryDrop(request)
+	return err
+}
+
+// EventID is "OnURLDropping"
+func (*OnURLDropping) EventID() string {
+	return "OnURLDropping"
+}
+
+// Decode sets the event decoded by DecodeOnURLDropping
+func (event *OnURLDropping) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnURLDropping(request)
+	return err
+}
+
+// EventID is "OnGhostChanged"
+func (*OnGhostChanged) EventID() string {
+	return "OnGhostChanged"
+}
+
+// Decode sets the event decoded by DecodeOnGhostChanged
+func (event *OnGhostChanged) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnGhostChanged(request)
+	return err
+}
+
+// EventID is "OnGhostCalled"
+func (*OnGhostCalled) EventID() string {
+	return "OnGhostCalled"
+}
+
+// Decode sets the event decoded by DecodeOnGhostCalled
+func (event *OnGhostCalled) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnGhostCalled(request)
+	return err
+}
+
+// EventID is "OnOtherGhostBooted"
+func (*OnOtherGhostBooted) EventID() string {
+	return "OnOtherGhostBooted"
+}
+
+// Decode sets the event decoded by DecodeOnOtherGhostBooted
+func (event *OnOtherGhostBooted) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnOtherGhostBooted(request)
+	return err
+}
+
+// EventID is "OnInstallBegin"
+func (*OnInstallBegin) EventID() string {
+	return "OnInstallBegin"
+}
+
+// Decode sets the event decoded by DecodeOnInstallBegin
+func (event *OnInstallBegin) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnInstallBegin(request)
+	return err
+}
+
+// EventID is "OnInstallComplete"
+func (*OnInstallComplete) EventID() string {
+	return "OnInstallComplete"
+}
+
+// Decode sets the event decoded by DecodeOnInstallComplete
+func (event *OnInstallComplete) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnInstallComplete(request)
+	return err
+}
+
+// EventID is "OnInstallFailure"
+func (*OnInstallFailure) EventID() string {
+	return "OnInstallFailure"
+}
+
+// Decode sets the event decoded by DecodeOnInstallFailure
+func (event *OnInstallFailure) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnInstallFailure(request)
+	return err
+}
+
+// EventID is "OnInstallRefuse"
+func (*OnInstallRefuse) EventID() string {
+	return "OnInstallRefuse"
+}
+
+// Decode sets the event decoded by DecodeOnInstallRefuse
+func (event *OnInstallRefuse) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnInstallRefuse(request)
+	return err
+}
+
+// EventID is "OnKeyPress"
+func (*OnKeyPress) EventID() string {
+	return "OnKeyPress"
+}
+
+// Decode sets the event decoded by DecodeOnKeyPress
+func (event *OnKeyPress) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeOnKeyPress(request)
+	return err
+}
+
+// EventID is "basewareversion"
+func (*BasewareVersion) EventID() string {
+	return "basewareversion"
+}
+
+// Decode sets the event decoded by DecodeBasewareVersion
+func (event *BasewareVersion) Decode(request shiori.Request) (err error) {
+	*event, err = DecodeBasewareVersion(request)
+	return err
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestOn(t *testing.T) {
+	mux := shiori.NewMux()
+	On(mux, func(event OnKeyPress, request shiori.Request) shiori.Response {
+		return shiori.OK(event.Key+":"+request.Reference(1), shiori.WithDefaultsFrom(request))
+	})
+	tests := []struct {
+		name      string
+		request   shiori.Request
+		wantCode  int
+		wantValue string
+	}{
+		{"decoded", shiori.NewRequest(shiori.GET, "OnKeyPress", "a", "65"), 200, "a:65"},
+		{"decode error", shiori.NewRequest(shiori.GET, "OnKeyPress", "a", "x"), 400, ""},
+		{"other event", shiori.NewRequest(shiori.GET, "OnBoot"), 204, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := mux.Serve(test.request)
+			if response.Code != test.wantCode || response.Value(0) != test.wantValue {
+				t.Errorf("response = %q, want %d %q", response.String(), test.wantCode, test.wantValue)
+			}
+		})
+	}
+}
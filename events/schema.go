@@ -0,0 +1,110 @@
+package events
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// ReferenceType is type of a Reference* value
+type ReferenceType int
+
+const (
+	// StringReference accepts any value
+	StringReference ReferenceType = iota
+	// IntReference accepts decimal integers
+	IntReference
+	// BoolReference accepts "0" or "1"
+	BoolReference
+)
+
+func (referenceType ReferenceType) String() string {
+	switch referenceType {
+	case StringReference:
+		return "string"
+	case IntReference:
+		return "int"
+	case BoolReference:
+		return "bool"
+	default:
+		return ""
+	}
+}
+
+func (referenceType ReferenceType) accepts(value string) bool {
+	switch referenceType {
+	case IntReference:
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case BoolReference:
+		return value == "0" || value == "1"
+	default:
+		return true
+	}
+}
+
+// ReferenceField is schema of one Reference* header
+type ReferenceField struct {
+	Index    int
+	Type     ReferenceType
+	Required bool
+}
+
+// ReferenceSchema is reference layout of an event
+type ReferenceSchema []ReferenceField
+
+// Validate checks the request against the schema
+func (schema ReferenceSchema) Validate(request shiori.Request) error {
+	id := request.EventID()
+	var problems []string
+	for _, field := range schema {
+		label := id + " Reference" + strconv.Itoa(field.Index)
+		value, ok := request.ReferenceOK(field.Index)
+		if !ok {
+			if field.Required {
+				problems = append(problems, label+" is required")
+			}
+			continue
+		}
+		if !field.Type.accepts(value) {
+			problems = append(problems, label+" expected "+field.Type.String()+", got '"+value+"'")
+		}
+	}
+	if len(problems) != 0 {
+		return SchemaError(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Schemas maps event IDs to reference schemas
+type Schemas map[string]ReferenceSchema
+
+// Register sets the schema of the event
+func (schemas Schemas) Register(id string, schema ReferenceSchema) {
+	sorted := append(ReferenceSchema(nil), schema...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+	schemas[id] = sorted
+}
+
+// Validate checks the request against the schema of its event; events without schema are always valid
+func (schemas Schemas) Validate(request shiori.Request) error {
+	schema, ok := schemas[request.EventID()]
+	if !ok {
+		return nil
+	}
+	return schema.Validate(request)
+}
+
+// SchemaError is reference schema violation
+type SchemaError string
+
+func (err SchemaError) Error() string {
+	return "SchemaError: " + string(err)
+}
+
+// Is reports SchemaError matches ErrDecode
+func (err SchemaError) Is(target error) bool {
+	return target == ErrDecode
+}
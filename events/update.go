@@ -0,0 +1,110 @@
+package events
+
+import (
+	"strconv"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// UpdateStage is stage of network update
+type UpdateStage int
+
+const (
+	// UpdateBegin is OnUpdateBegin
+	UpdateBegin UpdateStage = iota
+	// UpdateReady is OnUpdateReady (files to update are determined)
+	UpdateReady
+	// UpdateDownloading is OnUpdate.OnDownloadBegin
+	UpdateDownloading
+	// UpdateVerifying is OnUpdate.OnMD5CompareBegin / OnUpdate.OnMD5CompareComplete / OnUpdate.OnMD5CompareFailure
+	// (Result is "md5 miss" for the failure; OnUpdateFailure follows it)
+	UpdateVerifying
+	// UpdateComplete is OnUpdateComplete (finished)
+	UpdateComplete
+	// UpdateFailure is OnUpdateFailure (finished)
+	UpdateFailure
+)
+
+func (stage UpdateStage) String() string {
+	switch stage {
+	case UpdateBegin:
+		return "begin"
+	case UpdateReady:
+		return "ready"
+	case UpdateDownloading:
+		return "downloading"
+	case UpdateVerifying:
+		return "verifying"
+	case UpdateComplete:
+		return "complete"
+	case UpdateFailure:
+		return "failure"
+	default:
+		return ""
+	}
+}
+
+// UpdateProgress is network update progress reported by UpdateTracker
+type UpdateProgress struct {
+	Stage UpdateStage
+	// File is the file being downloaded or verified
+	File string
+	// Index is 0-based index of File
+	Index int
+	// Total is number of files to update (known from UpdateReady)
+	Total int
+	// Result is Reference0 of OnUpdateComplete ("none" or "changed") or OnUpdateFailure (reason),
+	// or "md5 miss" for OnUpdate.OnMD5CompareFailure
+	Result string
+}
+
+// Done reports whether the update has finished
+func (progress UpdateProgress) Done() bool {
+	return progress.Stage == UpdateComplete || progress.Stage == UpdateFailure
+}
+
+// UpdateTracker follows the update events after \![updatebymyself] and reports them to Progress
+type UpdateTracker struct {
+	// Progress is called for every observed update event
+	Progress func(UpdateProgress)
+	total    int
+}
+
+// Observe feeds a request to the tracker and returns whether it was an update event
+func (tracker *UpdateTracker) Observe(request shiori.Request) bool {
+	progress := UpdateProgress{Total: tracker.total}
+	switch request.EventID() {
+	case "OnUpdateBegin":
+		tracker.total = 0
+		progress = UpdateProgress{Stage: UpdateBegin}
+	case "OnUpdateReady":
+		progress.Stage = UpdateReady
+		if total, err := strconv.Atoi(request.Reference(0)); err == nil {
+			tracker.total = total
+			progress.Total = total
+		}
+	case "OnUpdate.OnDownloadBegin":
+		progress.Stage = UpdateDownloading
+		progress.File = request.Reference(0)
+		progress.Index, _ = strconv.Atoi(request.Reference(1))
+	case "OnUpdate.OnMD5CompareBegin", "OnUpdate.OnMD5CompareComplete":
+		progress.Stage = UpdateVerifying
+		progress.File = request.Reference(0)
+	case "OnUpdate.OnMD5CompareFailure":
+		progress.Stage = UpdateVerifying
+		progress.File = request.Reference(0)
+		progress.Result = "md5 miss"
+	case "OnUpdateComplete":
+		progress.Stage = UpdateComplete
+		progress.Result = request.Reference(0)
+	case "OnUpdateFailure":
+		progress.Stage = UpdateFailure
+		progress.Result = request.Reference(0)
+	default:
+		return false
+	}
+	if tracker.Progress != nil {
+		tracker.Progress(progress)
+	}
+	return true
+}
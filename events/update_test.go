@@ -0,0 +1,35 @@
+package events
+
+import (
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestUpdateTrackerMD5Failure(t *testing.T) {
+	var progresses []UpdateProgress
+	tracker := UpdateTracker{Progress: func(progress UpdateProgress) {
+		progresses = append(progresses, progress)
+	}}
+	for _, id := range []string{"OnUpdateBegin", "OnUpdate.OnMD5CompareFailure", "OnUpdateFailure"} {
+		request := shiori.NewRequest(shiori.NOTIFY, id, "file.txt")
+		if !tracker.Observe(request) {
+			t.Fatalf("Observe(%s) = false", id)
+		}
+	}
+	var done int
+	for _, progress := range progresses {
+		if progress.Done() {
+			done++
+		}
+	}
+	if done != 1 {
+		t.Errorf("terminal progresses = %d, want 1", done)
+	}
+	if md5 := progresses[1]; md5.Stage != UpdateVerifying || md5.Result != "md5 miss" || md5.File != "file.txt" {
+		t.Errorf("OnUpdate.OnMD5CompareFailure progress = %+v", md5)
+	}
+	if last := progresses[2]; last.Stage != UpdateFailure {
+		t.Errorf("OnUpdateFailure stage = %v", last.Stage)
+	}
+}
@@ -0,0 +1,144 @@
+// Package export wires the SHIORI DLL entry points load, request and unload to a Go Shiori.
+//
+// Register the Shiori in an init function of package main and build with -buildmode=c-shared:
+//
+//	func init() {
+//		export.Register(&myShiori{})
+//	}
+//
+//	func main() {}
+//
+// The entry points themselves are available on Windows only.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Shiori is a SHIORI module served through the DLL entry points
+type Shiori interface {
+	shiori.Handler
+	// Load is called with the directory the DLL is placed in
+	Load(dir string) error
+	// Unload is called before the DLL is released
+	Unload() error
+}
+
+var (
+	mutex      sync.Mutex
+	registered Shiori
+)
+
+// Fallback is the policy for response characters not representable in the response charset (such as emoji in Shift_JIS).
+// Set it before the baseware loads the DLL.
+var Fallback = shiori.FallbackUTF8
+
+// Register sets the Shiori served by the entry points
+func Register(s Shiori) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	registered = s
+}
+
+func current() Shiori {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return registered
+}
+
+// loadShiori decodes directory path given by the baseware in the system code page and calls Load
+func loadShiori(data []byte) (err error) {
+	s := current()
+	if s == nil {
+		return ExportError("no Shiori registered")
+	}
+	defer recoverError(&err)
+	return s.Load(decodePath(data))
+}
+
+// requestShiori decodes raw request message, calls Serve and encodes the response
+// in the charset of the request unless the response declares its own.
+// Malformed requests are answered with 400 Bad Request and failures with 500 Internal Server Error.
+func requestShiori(data []byte) []byte {
+	s := current()
+	if s == nil {
+		return encodeResponse(shiori.InternalError(ExportError("no Shiori registered")))
+	}
+	req, err := shiori.DecodeRequest(data)
+	if err != nil {
+		return encodeResponse(shiori.BadRequest(err.Error()))
+	}
+	response, err := serve(s, req)
+	if err != nil {
+		return encodeResponse(shiori.InternalError(err, shiori.WithDefaultsFrom(req)))
+	}
+	if charset := req.Charset(); charset != "" && response.Charset() == "" {
+		// answer in the charset of the baseware; headers may be shared by the handler
+		response.Headers = shiori.ResponseHeaders(shiori.Headers(response.Headers).Clone())
+		response.Headers[shiori.HeaderCharset] = charset
+	}
+	return encodeResponse(response)
+}
+
+// unloadShiori calls Unload
+func unloadShiori() (err error) {
+	s := current()
+	if s == nil {
+		return nil
+	}
+	defer recoverError(&err)
+	return s.Unload()
+}
+
+func serve(s Shiori, req shiori.Request) (response shiori.Response, err error) {
+	defer recoverError(&err)
+	return s.Serve(req), nil
+}
+
+// encodeResponse encodes the response in its charset applying Fallback, falling back to UTF-8 error response
+func encodeResponse(response shiori.Response) []byte {
+	data, err := response.EncodeWith(Fallback)
+	if err != nil {
+		data, _ = shiori.InternalError(err).Encode()
+	}
+	return data
+}
+
+func decodePath(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	if enc, err := shiori.LookupCharset(shiori.LegacyCharset); err == nil {
+		if decoded, err := enc.NewDecoder().Bytes(data); err == nil {
+			return string(decoded)
+		}
+	}
+	return string(data)
+}
+
+// recoverError turns a panic into error, which must not cross the DLL boundary
+func recoverError(err *error) {
+	if recovered := recover(); recovered != nil {
+		*err = ExportError(fmt.Sprint("panic: ", recovered))
+	}
+}
+
+// ExportError is failure in the DLL entry points
+type ExportError string
+
+func (err ExportError) Error() string {
+	return "ExportError: " + string(err)
+}
+
+// ErrExport matches ExportError with errors.Is
+var ErrExport = errors.New("export: export error")
+
+// Is reports ExportError matches ErrExport
+func (err ExportError) Is(target error) bool {
+	return target == ErrExport
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+type testShiori struct {
+	response shiori.Response
+}
+
+func (s *testShiori) Load(dir string) error { return nil }
+
+func (s *testShiori) Unload() error { return nil }
+
+func (s *testShiori) Serve(request shiori.Request) shiori.Response { return s.response }
+
+func TestRequestCharset(t *testing.T) {
+	request := []byte("GET SHIORI/3.0\r\nCharset: Shift_JIS\r\nID: OnBoot\r\n\r\n")
+	tests := []struct {
+		name        string
+		response    shiori.Response
+		wantCharset string
+		wantValue   string
+	}{
+		{"from request", shiori.OK("表"), "Shift_JIS", "表"},
+		{"declared by response", shiori.OK("表", shiori.WithHeader(shiori.HeaderCharset, "UTF-8")), "UTF-8", "表"},
+		{"unrepresentable in request charset", shiori.OK("表😀"), "UTF-8", "表😀"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := len(test.response.Headers)
+			Register(&testShiori{response: test.response})
+			defer Register(nil)
+			response, err := shiori.DecodeResponse(requestShiori(request))
+			if err != nil {
+				t.Fatalf("DecodeResponse() error = %v", err)
+			}
+			if response.Charset() != test.wantCharset || response.Value(0) != test.wantValue {
+				t.Errorf("response = %q, want Charset %s and Value %s", response.String(), test.wantCharset, test.wantValue)
+			}
+			if len(test.response.Headers) != headers {
+				t.Error("headers of the handler response modified")
+			}
+		})
+	}
+}
+
+func TestTemplateShiori(t *testing.T) {
+	dir := t.TempDir()
+	templates := `{"OnBoot": [{"script": "\\h\\s[0]Hello.\\e"}], "*": [{"script": ""}]}`
+	if err := os.WriteFile(filepath.Join(dir, "templates.json"), []byte(templates), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mux := shiori.NewMux()
+	mux.HandleFunc("OnClose", func(request shiori.Request) shiori.Response {
+		return shiori.OK("\\h\\s[0]Bye.\\-", shiori.WithDefaultsFrom(request))
+	})
+	s := &TemplateShiori{File: "templates.json", Mux: mux}
+	if err := s.Load(dir); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	tests := []struct {
+		id        string
+		wantCode  int
+		wantValue string
+	}{
+		{"OnBoot", 200, "\\h\\s[0]Hello.\\e"},
+		{"OnClose", 200, "\\h\\s[0]Bye.\\-"},
+		{"OnUnknown", 204, ""},
+	}
+	for _, test := range tests {
+		response := s.Serve(shiori.NewRequest(shiori.GET, test.id))
+		if response.Code != test.wantCode || response.Value(0) != test.wantValue {
+			t.Errorf("Serve(%s) = %q, want %d with Value %q", test.id, response.String(), test.wantCode, test.wantValue)
+		}
+	}
+	if err := (&TemplateShiori{File: "missing.json"}).Load(dir); err == nil {
+		t.Error("Load() of missing file succeeded")
+	}
+}
+
+type panickingShiori struct{ testShiori }
+
+func (s *panickingShiori) Load(dir string) error { panic("broken") }
+
+func TestLoadErrors(t *testing.T) {
+	Register(nil)
+	if err := loadShiori([]byte("C:\\ghost\\master\\")); !errors.Is(err, ErrExport) {
+		t.Errorf("loadShiori() without Shiori error = %v, want ErrExport", err)
+	}
+	Register(&panickingShiori{})
+	defer Register(nil)
+	if err := loadShiori([]byte("C:\\ghost\\master\\")); !errors.Is(err, ErrExport) {
+		t.Errorf("loadShiori() of panicking Shiori error = %v, want ErrExport", err)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build windows && cgo
+
+package export
+
+/*
+#include <windows.h>
+*/
+import "C"
+
+import "unsafe"
+
+// takeGlobal copies the memory block given by the baseware and frees it, as the DLL owns it
+func takeGlobal(h C.HGLOBAL, length C.long) []byte {
+	if h == nil {
+		return nil
+	}
+	data := C.GoBytes(unsafe.Pointer(C.GlobalLock(h)), C.int(length))
+	C.GlobalUnlock(h)
+	C.GlobalFree(h)
+	return data
+}
+
+// newGlobal allocates the memory block returned to the baseware, which frees it
+func newGlobal(data []byte) C.HGLOBAL {
+	h := C.GlobalAlloc(C.GMEM_FIXED, C.SIZE_T(len(data)))
+	if h == nil {
+		return nil
+	}
+	if len(data) > 0 {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(h)), len(data)), data)
+	}
+	return h
+}
+
+//export load
+func load(h C.HGLOBAL, length C.long) C.BOOL {
+	if err := loadShiori(takeGlobal(h, length)); err != nil {
+		return C.FALSE
+	}
+	return C.TRUE
+}
+
+//export request
+func request(h C.HGLOBAL, length *C.long) C.HGLOBAL {
+	data := requestShiori(takeGlobal(h, *length))
+	*length = C.long(len(data))
+	return newGlobal(data)
+}
+
+//export unload
+func unload() C.BOOL {
+	if err := unloadShiori(); err != nil {
+		return C.FALSE
+	}
+	return C.TRUE
+}
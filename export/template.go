@@ -0,0 +1,58 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// TemplateShiori is Shiori answering from a templates file in the DLL directory (see shiori.Templates),
+// so that a simple ghost needs no Go code beyond
+//
+//	func init() {
+//		export.Register(&export.TemplateShiori{File: "templates.json"})
+//	}
+//
+//	func main() {}
+type TemplateShiori struct {
+	// File is path of the templates JSON relative to the DLL directory
+	File string
+	// Mux holds custom handlers layered on top of the templates (its Fallback is replaced by the templates on Load)
+	Mux *shiori.Mux
+}
+
+// Load reads and compiles the templates file
+func (s *TemplateShiori) Load(dir string) error {
+	file, err := os.Open(filepath.Join(dir, s.File))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	templates, err := shiori.LoadTemplates(file)
+	if err != nil {
+		return err
+	}
+	handler, err := templates.Handler()
+	if err != nil {
+		return err
+	}
+	if s.Mux == nil {
+		s.Mux = shiori.NewMux()
+	}
+	s.Mux.Fallback = handler
+	return nil
+}
+
+// Serve dispatches the request to the custom handlers, or the templates
+func (s *TemplateShiori) Serve(request shiori.Request) shiori.Response {
+	if s.Mux == nil {
+		return shiori.InternalError(ExportError("templates not loaded"), shiori.WithDefaultsFrom(request))
+	}
+	return s.Mux.Serve(request)
+}
+
+// Unload does nothing
+func (s *TemplateShiori) Unload() error {
+	return nil
+}
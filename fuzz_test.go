@@ -0,0 +1,47 @@
+package shiori_test
+
+import (
+	"reflect"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+	"github.com/Narazaka/shiorigo/shioritest"
+)
+
+func FuzzParseRequest(f *testing.F) {
+	shioritest.AddSeeds(f, shioritest.RequestSeeds()...)
+	f.Fuzz(func(t *testing.T, message string) {
+		shiori.Parser{Lenient: true, Folded: true}.ParseRequest(message)
+		shiori.DecodeRequest([]byte(message))
+		request, err := shiori.ParseRequest(message)
+		if err != nil {
+			return
+		}
+		reparsed, err := shiori.ParseRequest(request.String())
+		if err != nil {
+			t.Fatalf("ParseRequest(%q) of serialized request error = %v", request.String(), err)
+		}
+		if !reflect.DeepEqual(reparsed, request) {
+			t.Errorf("round trip = %q, want %q", reparsed.String(), request.String())
+		}
+	})
+}
+
+func FuzzParseResponse(f *testing.F) {
+	shioritest.AddSeeds(f, shioritest.ResponseSeeds()...)
+	f.Fuzz(func(t *testing.T, message string) {
+		shiori.Parser{Lenient: true, Folded: true}.ParseResponse(message)
+		shiori.DecodeResponse([]byte(message))
+		response, err := shiori.ParseResponse(message)
+		if err != nil {
+			return
+		}
+		reparsed, err := shiori.ParseResponse(response.String())
+		if err != nil {
+			t.Fatalf("ParseResponse(%q) of serialized response error = %v", response.String(), err)
+		}
+		if !reflect.DeepEqual(reparsed, response) {
+			t.Errorf("round trip = %q, want %q", reparsed.String(), response.String())
+		}
+	})
+}
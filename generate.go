@@ -0,0 +1,92 @@
+package shiori
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+)
+
+// Request and Response implement testing/quick.Generator so that downstream
+// property tests can draw random but well-formed messages, e.g.
+//
+//	quick.Check(shioritest.RequestRoundTrip, nil)
+
+var generateCharsets = []string{"UTF-8", "Shift_JIS", "EUC-JP", "ISO-2022-JP", "windows-31j", "utf-8", "x-unknown-charset"}
+
+var generateIDs = []string{"OnBoot", "OnClose", "OnSecondChange", "OnMinuteChange", "OnMouseClick", "OnMouseMove", "OnChoiceSelect", "version", "name", "craftman"}
+
+var generateCodes = []int{200, 204, 310, 311, 312, 400, 500}
+
+// header name characters including unusual but syntactically valid ones
+const generateNameChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_.!#$%&'*+^`|~ "
+
+// header value characters including multibyte and control-ish ones (but no CR/LF)
+const generateValueChars = "abcxyz0123456789 :,\\[]_-\t\x01あいうえお漢字😀"
+
+func generateVersion(rand *rand.Rand) string {
+	return strconv.Itoa(rand.Intn(4)) + "." + strconv.Itoa(rand.Intn(10))
+}
+
+func generateString(rand *rand.Rand, chars string, minLength int, maxLength int) string {
+	runes := []rune(chars)
+	length := minLength + rand.Intn(maxLength-minLength+1)
+	str := make([]rune, length)
+	for i := range str {
+		str[i] = runes[rand.Intn(len(runes))]
+	}
+	return string(str)
+}
+
+func generateHeaders(rand *rand.Rand, size int) Headers {
+	headers := Headers{}
+	if rand.Intn(2) == 0 {
+		headers[HeaderCharset] = generateCharsets[rand.Intn(len(generateCharsets))]
+	}
+	if rand.Intn(2) == 0 {
+		headers[HeaderSender] = generateString(rand, generateValueChars, 0, 16)
+	}
+	references := rand.Intn(size + 1)
+	if rand.Intn(10) == 0 {
+		// huge reference counts
+		references *= 100
+	}
+	for i := 0; i < references; i++ {
+		headers[HeaderReferencePrefix+strconv.Itoa(i)] = generateString(rand, generateValueChars, 0, size)
+	}
+	others := rand.Intn(size/4 + 1)
+	for i := 0; i < others; i++ {
+		// a leading space would be ambiguous with the ": " separator, so start with a letter
+		name := "X" + generateString(rand, generateNameChars, 0, 16)
+		headers[name] = generateString(rand, generateValueChars, 0, size)
+	}
+	return headers
+}
+
+// Generate makes random valid Request (implements testing/quick.Generator)
+func (Request) Generate(rand *rand.Rand, size int) reflect.Value {
+	methods := []Method{GET, NOTIFY}
+	headers := generateHeaders(rand, size)
+	headers[HeaderID] = generateIDs[rand.Intn(len(generateIDs))]
+	request := Request{
+		Method:   methods[rand.Intn(len(methods))],
+		Protocol: SHIORI,
+		Version:  generateVersion(rand),
+		Headers:  RequestHeaders(headers),
+	}
+	return reflect.ValueOf(request)
+}
+
+// Generate makes random valid Response (implements testing/quick.Generator)
+func (Response) Generate(rand *rand.Rand, size int) reflect.Value {
+	headers := generateHeaders(rand, size)
+	if rand.Intn(2) == 0 {
+		headers[HeaderValue] = generateString(rand, generateValueChars, 0, size)
+	}
+	response := Response{
+		Code:     generateCodes[rand.Intn(len(generateCodes))],
+		Protocol: SHIORI,
+		Version:  generateVersion(rand),
+		Headers:  ResponseHeaders(headers),
+	}
+	return reflect.ValueOf(response)
+}
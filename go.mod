@@ -0,0 +1,5 @@
+module github.com/Narazaka/shiorigo
+
+go 1.21
+
+require golang.org/x/text v0.21.0
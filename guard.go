@@ -0,0 +1,77 @@
+package shiori
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultDisallowedControls are characters which break SHIORI message framing
+const DefaultDisallowedControls = "\x00\r\n"
+
+// ResponseGuard checks responses before serialization
+type ResponseGuard struct {
+	// MaxSize limits serialized response size in bytes (no limit when 0)
+	MaxSize int
+	// Truncate shortens Value header to fit MaxSize instead of rejecting the response
+	Truncate bool
+	// DisallowedControls are characters rejected in header names and values (DefaultDisallowedControls when empty)
+	DisallowedControls string
+}
+
+// Apply returns the response as is if it passes the guard, the truncated response if allowed,
+// or 500 Internal Server Error response with ErrorDescription diagnostics otherwise
+func (guard ResponseGuard) Apply(response Response) Response {
+	disallowed := guard.DisallowedControls
+	if disallowed == "" {
+		disallowed = DefaultDisallowedControls
+	}
+	for key, value := range response.Headers {
+		if strings.ContainsAny(key, disallowed) || strings.ContainsAny(value, disallowed) {
+			return guard.reject(response, "header "+strconv.Quote(key)+" contains disallowed control character")
+		}
+	}
+	if guard.MaxSize <= 0 {
+		return response
+	}
+	size := len(response.String())
+	if size <= guard.MaxSize {
+		return response
+	}
+	if guard.Truncate {
+		if value, ok := response.Headers[HeaderValue]; ok && len(value) >= size-guard.MaxSize {
+			truncated := response
+			truncated.Headers = ResponseHeaders(Headers(response.Headers).Clone())
+			truncated.Headers[HeaderValue] = truncateUTF8(value, len(value)-(size-guard.MaxSize))
+			return truncated
+		}
+	}
+	return guard.reject(response, "response size "+strconv.Itoa(size)+" exceeds limit "+strconv.Itoa(guard.MaxSize))
+}
+
+func (guard ResponseGuard) reject(response Response, description string) Response {
+	rejected := InternalError(ResponseGuardError(description))
+	rejected.Version = response.Version
+	if charset := response.Charset(); charset != "" {
+		rejected.Headers[HeaderCharset] = charset
+	}
+	return rejected
+}
+
+// truncateUTF8 cuts str to at most size bytes without splitting a character
+func truncateUTF8(str string, size int) string {
+	if len(str) <= size {
+		return str
+	}
+	for size > 0 && !utf8.RuneStart(str[size]) {
+		size--
+	}
+	return str[:size]
+}
+
+// ResponseGuardError is response rejected by ResponseGuard
+type ResponseGuardError string
+
+func (err ResponseGuardError) Error() string {
+	return "ResponseGuardError: " + string(err)
+}
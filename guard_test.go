@@ -0,0 +1,56 @@
+package shiori
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestResponseGuard(t *testing.T) {
+	response := OK("あいうえお", WithHeader(HeaderCharset, "Shift_JIS"))
+	size := len(response.String())
+	tests := []struct {
+		name            string
+		guard           ResponseGuard
+		response        Response
+		wantCode        int
+		wantValue       string
+		wantDescription string
+	}{
+		{"within limit", ResponseGuard{MaxSize: size}, response, 200, "あいうえお", ""},
+		{"no limit", ResponseGuard{}, response, 200, "あいうえお", ""},
+		{"too large", ResponseGuard{MaxSize: size - 4}, response, 500, "", "response size " + strconv.Itoa(size) + " exceeds limit " + strconv.Itoa(size-4)},
+		// cutting 4 bytes would split う, so the whole character goes
+		{"truncated at character boundary", ResponseGuard{MaxSize: size - 4, Truncate: true}, response, 200, "あいう", ""},
+		{"value too short to truncate", ResponseGuard{MaxSize: 10, Truncate: true}, response, 500, "", "response size " + strconv.Itoa(size) + " exceeds limit 10"},
+		{"control character", ResponseGuard{}, OK("a\x00b", WithHeader(HeaderCharset, "Shift_JIS")), 500, "", `header "Value" contains disallowed control character`},
+		{"custom controls", ResponseGuard{DisallowedControls: "\x1b"}, OK("a\x1bb", WithHeader(HeaderCharset, "Shift_JIS")), 500, "", `header "Value" contains disallowed control character`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guarded := test.guard.Apply(test.response)
+			if guarded.Code != test.wantCode || guarded.Value(0) != test.wantValue {
+				t.Fatalf("Apply() = %q, want %d with Value %q", guarded.String(), test.wantCode, test.wantValue)
+			}
+			if guarded.Charset() != "Shift_JIS" || guarded.Version != test.response.Version {
+				t.Errorf("Apply() = %q, want Charset and version of the response", guarded.String())
+			}
+			if description := guarded.Headers[HeaderErrorDescription]; !strings.HasSuffix(description, test.wantDescription) || (description == "") != (test.wantDescription == "") {
+				t.Errorf("ErrorDescription = %q, want %q", description, test.wantDescription)
+			}
+			if test.guard.MaxSize > 0 && guarded.Code == 200 && len(guarded.String()) > test.guard.MaxSize {
+				t.Errorf("Apply() size = %d, want at most %d", len(guarded.String()), test.guard.MaxSize)
+			}
+		})
+	}
+	if response.Value(0) != "あいうえお" {
+		t.Errorf("Apply() modified the response: %q", response.String())
+	}
+}
+
+func TestResponseGuardError(t *testing.T) {
+	if err := error(ResponseGuardError("too large")); !errors.Is(err, ErrGuard) {
+		t.Errorf("ResponseGuardError does not match ErrGuard")
+	}
+}
@@ -0,0 +1,56 @@
+package shiori
+
+// Standard SHIORI/3.0 (and 2.x) header names.
+// They are untyped string constants so that they can index Headers directly.
+const (
+	// HeaderCharset is Charset header
+	HeaderCharset = "Charset"
+	// HeaderSender is Sender header
+	HeaderSender = "Sender"
+	// HeaderSenderType is SenderType header
+	HeaderSenderType = "SenderType"
+	// HeaderID is ID header
+	HeaderID = "ID"
+	// HeaderBaseID is BaseID header
+	HeaderBaseID = "BaseID"
+	// HeaderSecurityLevel is SecurityLevel header
+	HeaderSecurityLevel = "SecurityLevel"
+	// HeaderSecurityOrigin is SecurityOrigin header
+	HeaderSecurityOrigin = "SecurityOrigin"
+	// HeaderStatus is Status header
+	HeaderStatus = "Status"
+	// HeaderReferencePrefix is prefix of Reference* headers
+	HeaderReferencePrefix = "Reference"
+	// HeaderValue is Value header
+	HeaderValue = "Value"
+	// HeaderValueNotify is ValueNotify header
+	HeaderValueNotify = "ValueNotify"
+	// HeaderMarker is Marker header
+	HeaderMarker = "Marker"
+	// HeaderErrorLevel is ErrorLevel header
+	HeaderErrorLevel = "ErrorLevel"
+	// HeaderErrorDescription is ErrorDescription header
+	HeaderErrorDescription = "ErrorDescription"
+	// HeaderBalloonOffset is BalloonOffset header
+	HeaderBalloonOffset = "BalloonOffset"
+	// HeaderAge is Age header
+	HeaderAge = "Age"
+	// HeaderEvent is Event header (SHIORI/2.x GET Sentence / NOTIFY)
+	HeaderEvent = "Event"
+	// HeaderSentence is Sentence header (SHIORI/2.x script)
+	HeaderSentence = "Sentence"
+	// HeaderWord is Word header (SHIORI/2.x GET Word / TEACH)
+	HeaderWord = "Word"
+	// HeaderType is Type header (SHIORI/2.x GET Word)
+	HeaderType = "Type"
+	// HeaderString is String header (SHIORI/2.x GET String)
+	HeaderString = "String"
+	// HeaderTo is To header (SHIORI/2.x communication target)
+	HeaderTo = "To"
+	// HeaderGhost is Ghost header (SHIORI/2.x NOTIFY OwnerGhostName / OtherGhostName)
+	HeaderGhost = "Ghost"
+	// HeaderSSTPPassThruPrefix is prefix of X-SSTP-PassThru-* headers
+	HeaderSSTPPassThruPrefix = "X-SSTP-PassThru-"
+	// HeaderNoContentReason is X-Shiori-NoContent-Reason debug header telling why the response is 204 No Content (see Mux.Debug)
+	HeaderNoContentReason = "X-Shiori-NoContent-Reason"
+)
@@ -0,0 +1,63 @@
+package shiori
+
+import "runtime/debug"
+
+// Info is SHIORI identification answered by GET version/name/craftman/craftmanw
+type Info struct {
+	Name      string
+	Version   string
+	Craftman  string
+	CraftmanW string
+}
+
+// NewInfo makes Info whose Version is the main module version from build info
+func NewInfo(name string, craftman string, craftmanw string) Info {
+	return Info{
+		Name:      name,
+		Version:   ModuleVersion(),
+		Craftman:  craftman,
+		CraftmanW: craftmanw,
+	}
+}
+
+// ModuleVersion is the main module version embedded by the go command (empty for development builds)
+func ModuleVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok || buildInfo.Main.Version == "(devel)" {
+		return ""
+	}
+	return buildInfo.Main.Version
+}
+
+// Respond answers GET version/name/craftman/craftmanw request.
+// ok is false for other requests or when the requested resource is empty.
+//
+// The SHIORI/2.x "GET Version" request is answered with ID, Craftman and Version headers
+// in a SHIORI/3.0 response, telling the baseware that SHIORI/3.0 is supported.
+func (info Info) Respond(request Request) (response Response, ok bool) {
+	if request.Method == GETVersion {
+		response = NewResponse(200, WithHeader(HeaderID, info.Name), WithHeader("Craftman", info.Craftman), WithHeader("Version", info.Version))
+		if charset := request.Charset(); charset != "" {
+			response.Headers[HeaderCharset] = charset
+		}
+		return response, true
+	}
+	if request.Method != GET {
+		return response, false
+	}
+	var value string
+	switch request.Headers[HeaderID] {
+	case "version":
+		value = info.Version
+	case "name":
+		value = info.Name
+	case "craftman":
+		value = info.Craftman
+	case "craftmanw":
+		value = info.CraftmanW
+	}
+	if value == "" {
+		return response, false
+	}
+	return OK(value, WithDefaultsFrom(request)), true
+}
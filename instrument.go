@@ -0,0 +1,49 @@
+package shiori
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ParseEvent describes one parse for ParseHook
+type ParseEvent struct {
+	// Raw is the parsed message (the raw bytes as is when DecodeRequest or DecodeResponse failed to decode them)
+	Raw string
+	// Request is the result of request parsing (nil for response parsing)
+	Request *Request
+	// Response is the result of response parsing (nil for request parsing)
+	Response *Response
+	// Err is the parse error, or CharsetError of DecodeRequest and DecodeResponse
+	Err error
+	// Duration is time spent for the parse (0 when decoding failed)
+	Duration time.Duration
+}
+
+// ParseHook is called after a parse, e.g. to capture malformed traffic samples
+type ParseHook func(event ParseEvent)
+
+var globalParseHook atomic.Value // of ParseHook
+
+// SetParseHook sets hook called after every parse by any Parser (including ParseRequest and ParseResponse).
+// Pass nil to remove it. The hook may be called concurrently.
+func SetParseHook(hook ParseHook) {
+	globalParseHook.Store(hook)
+}
+
+func loadParseHook() ParseHook {
+	hook, _ := globalParseHook.Load().(ParseHook)
+	return hook
+}
+
+func (parser Parser) hooked() bool {
+	return parser.Hook != nil || loadParseHook() != nil
+}
+
+func (parser Parser) notify(event ParseEvent) {
+	if hook := loadParseHook(); hook != nil {
+		hook(event)
+	}
+	if parser.Hook != nil {
+		parser.Hook(event)
+	}
+}
@@ -0,0 +1,45 @@
+package shiori
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParserHook(t *testing.T) {
+	var events []ParseEvent
+	parser := Parser{Hook: func(event ParseEvent) { events = append(events, event) }}
+	if _, err := parser.ParseRequest(benchRequestMessage); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseResponse("SHIORI/3.0 OK\r\n\r\n"); err == nil {
+		t.Fatal("ParseResponse() of broken status line succeeded")
+	}
+	if len(events) != 2 {
+		t.Fatalf("hook called %d times, want 2", len(events))
+	}
+	request := events[0]
+	if request.Raw != benchRequestMessage || request.Request == nil || request.Request.EventID() != "OnMouseDoubleClick" || request.Response != nil || request.Err != nil || request.Duration < 0 {
+		t.Errorf("request event = %+v", request)
+	}
+	response := events[1]
+	if response.Raw != "SHIORI/3.0 OK\r\n\r\n" || response.Response == nil || response.Request != nil || !errors.Is(response.Err, ErrParse) {
+		t.Errorf("response event = %+v", response)
+	}
+}
+
+func TestSetParseHook(t *testing.T) {
+	var global, local int
+	SetParseHook(func(event ParseEvent) { global++ })
+	defer SetParseHook(nil)
+	ParseRequest(benchRequestMessage)
+	ParseResponse(benchResponseMessage)
+	Parser{Hook: func(event ParseEvent) { local++ }}.ParseRequest(benchRequestMessage)
+	if global != 3 || local != 1 {
+		t.Errorf("global hook called %d times and parser hook %d times, want 3 and 1", global, local)
+	}
+	SetParseHook(nil)
+	ParseRequest(benchRequestMessage)
+	if global != 3 {
+		t.Errorf("global hook called after removal")
+	}
+}
@@ -0,0 +1,124 @@
+package shiori
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// LazyRequest is raw SHIORI/x.x Request Message whose header values are decoded from its charset on first access.
+// Only the request line and header keys are parsed up front,
+// which saves transcoding the references of NOTIFY floods that are never read.
+// It is not safe for concurrent use.
+type LazyRequest struct {
+	Method   Method
+	Protocol Protocol
+	Version  string
+
+	// raw holds undecoded header values
+	raw map[string][]byte
+	// values caches decoded header values
+	values map[string]string
+	// decoder is nil for UTF-8 messages
+	decoder *encoding.Decoder
+}
+
+// DecodeRequestLazy is like DecodeRequest but defers decoding of header values (see LazyRequest).
+// Messages are parsed strictly as by Parser{} and parse hooks are not called.
+func DecodeRequestLazy(data []byte) (*LazyRequest, error) {
+	charset, ok := findCharset(data)
+	if !ok {
+		charset = "UTF-8"
+		if !utf8.Valid(data) {
+			charset = LegacyCharset
+		}
+	}
+	enc, err := LookupCharset(charset)
+	if err != nil {
+		return nil, err
+	}
+	// CR and LF never appear inside multibyte characters of supported charsets (see findCharset)
+	lines := bytes.Split(data, []byte("\r\n"))
+	request, err := parseRequestLine(string(lines[0]))
+	if err != nil {
+		return nil, err
+	}
+	lazy := &LazyRequest{
+		Method:   request.Method,
+		Protocol: request.Protocol,
+		Version:  request.Version,
+		raw:      make(map[string][]byte, len(lines)-1),
+		values:   map[string]string{},
+	}
+	if enc != unicode.UTF8 {
+		lazy.decoder = enc.NewDecoder()
+	}
+	for _, line := range lines[1:] {
+		if len(line) == 0 {
+			break
+		}
+		// same as headerRe: key without colon, then ": " and value without LF
+		colon := bytes.IndexByte(line, ':')
+		if colon <= 0 || colon+1 == len(line) || line[colon+1] != ' ' || bytes.IndexByte(line, '\n') >= 0 {
+			return nil, ParseHeaderError("header line parse failed: " + string(line))
+		}
+		lazy.raw[string(line[:colon])] = line[colon+2:]
+	}
+	return lazy, nil
+}
+
+// Has reports whether the header exists
+func (request *LazyRequest) Has(key string) bool {
+	_, ok := request.raw[key]
+	return ok
+}
+
+// Header decodes the header value ("" if absent)
+func (request *LazyRequest) Header(key string) (string, error) {
+	if value, ok := request.values[key]; ok {
+		return value, nil
+	}
+	raw, ok := request.raw[key]
+	if !ok {
+		return "", nil
+	}
+	value := string(raw)
+	if request.decoder != nil {
+		decoded, err := request.decoder.Bytes(raw)
+		if err != nil {
+			return "", CharsetError("cannot decode " + key + " header: " + err.Error())
+		}
+		value = string(decoded)
+	}
+	request.values[key] = value
+	return value, nil
+}
+
+// EventID decodes the event name: ID header, or Event header of SHIORI/2.x requests
+func (request *LazyRequest) EventID() (string, error) {
+	if request.Has(HeaderID) {
+		return request.Header(HeaderID)
+	}
+	return request.Header(HeaderEvent)
+}
+
+// Reference decodes Reference* header
+func (request *LazyRequest) Reference(i int) (string, error) {
+	return request.Header(HeaderReferencePrefix + strconv.Itoa(i))
+}
+
+// Request decodes all the headers into Request
+func (request *LazyRequest) Request() (Request, error) {
+	headers := make(RequestHeaders, len(request.raw))
+	for key := range request.raw {
+		value, err := request.Header(key)
+		if err != nil {
+			return Request{}, err
+		}
+		headers[key] = value
+	}
+	return Request{Method: request.Method, Protocol: request.Protocol, Version: request.Version, Headers: headers}, nil
+}
@@ -0,0 +1,96 @@
+package shiori
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDecodeRequestLazy(t *testing.T) {
+	message := "NOTIFY SHIORI/3.0\r\nCharset: Shift_JIS\r\nSender: SSP\r\nID: OnTest\r\nReference0: 表示\r\nReference1: \r\n\r\n"
+	for _, charset := range []string{"Shift_JIS", "UTF-8", "EUC-JP"} {
+		t.Run(charset, func(t *testing.T) {
+			data, err := EncodeMessage(strings.Replace(message, "Shift_JIS", charset, 1), charset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lazy, err := DecodeRequestLazy(data)
+			if err != nil {
+				t.Fatalf("DecodeRequestLazy() error = %v", err)
+			}
+			if id, err := lazy.EventID(); id != "OnTest" || err != nil {
+				t.Errorf("EventID() = %q, %v, want OnTest", id, err)
+			}
+			if reference, err := lazy.Reference(0); reference != "表示" || err != nil {
+				t.Errorf("Reference(0) = %q, %v, want 表示", reference, err)
+			}
+			if !lazy.Has("Reference1") || lazy.Has("Reference2") {
+				t.Errorf("Has() does not tell empty Reference1 from absent Reference2")
+			}
+			request, err := lazy.Request()
+			if err != nil {
+				t.Fatalf("Request() error = %v", err)
+			}
+			want, _ := DecodeRequest(data)
+			if !reflect.DeepEqual(request, want) {
+				t.Errorf("Request() = %q, want %q", request.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestDecodeRequestLazyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    error
+	}{
+		{"request line", "GET SHIORI\r\nID: OnBoot\r\n\r\n", ErrParse},
+		{"method", "JUMP SHIORI/3.0\r\nID: OnBoot\r\n\r\n", ErrInvalidMethod},
+		{"header", "GET SHIORI/3.0\r\nID:OnBoot\r\n\r\n", ErrParse},
+		{"charset", "GET SHIORI/3.0\r\nCharset: KOI9\r\nID: OnBoot\r\n\r\n", ErrCharset},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := DecodeRequestLazy([]byte(test.message))
+			if !errors.Is(err, test.want) {
+				t.Errorf("DecodeRequestLazy() error = %v, want %v", err, test.want)
+			}
+			if _, eager := DecodeRequest([]byte(test.message)); (eager == nil) != (err == nil) {
+				t.Errorf("DecodeRequest() error = %v, DecodeRequestLazy() error = %v", eager, err)
+			}
+		})
+	}
+}
+
+var lazyBenchRequest = func() []byte {
+	var builder strings.Builder
+	builder.WriteString("NOTIFY SHIORI/3.0\r\nCharset: Shift_JIS\r\nSender: SSP\r\nID: OnOtherGhostTalk\r\n")
+	for i := 0; i < 8; i++ {
+		builder.WriteString("Reference" + strconv.Itoa(i) + ": 他のゴーストの会話です。\\h\\s[0]こんにちは。\\e\r\n")
+	}
+	builder.WriteString("\r\n")
+	data, err := EncodeMessage(builder.String(), "Shift_JIS")
+	if err != nil {
+		panic(err)
+	}
+	return data
+}()
+
+func BenchmarkDecodeRequest(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		request, _ := DecodeRequest(lazyBenchRequest)
+		_ = request.EventID()
+	}
+}
+
+func BenchmarkDecodeRequestLazy(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		request, _ := DecodeRequestLazy(lazyBenchRequest)
+		_, _ = request.EventID()
+	}
+}
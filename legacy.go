@@ -0,0 +1,60 @@
+package shiori
+
+// WordFunc supplies a word of the type (Type header of SHIORI/2.x GET Word, such as "\ms"), or "" if it has none
+type WordFunc func(wordType string, request Request) string
+
+// StatusFunc supplies the status answered to SHIORI/2.x GET Status (comma separated values), or "" if it has none
+type StatusFunc func(request Request) string
+
+// WordHandler makes Handler answering SHIORI/2.x GET Word requests with Word header.
+// Empty words and other requests are answered with 204 No Content.
+func WordHandler(word WordFunc) Handler {
+	return HandlerFunc(func(request Request) Response {
+		if request.Method != GETWord {
+			return noContent(request)
+		}
+		return legacyResponse(request, HeaderWord, word(request.Headers[HeaderType], request))
+	})
+}
+
+// StatusHandler makes Handler answering SHIORI/2.x GET Status requests with Status header.
+// Empty statuses and other requests are answered with 204 No Content.
+func StatusHandler(status StatusFunc) Handler {
+	return HandlerFunc(func(request Request) Response {
+		if request.Method != GETStatus {
+			return noContent(request)
+		}
+		return legacyResponse(request, HeaderStatus, status(request))
+	})
+}
+
+func legacyResponse(request Request, header string, value string) Response {
+	if value == "" {
+		return noContent(request)
+	}
+	return NewResponse(200, WithDefaultsFrom(request), WithHeader(header, value))
+}
+
+// HandleWord registers the word supplier for SHIORI/2.x GET Word requests, which have no event ID.
+// It panics if one is already registered.
+func (mux *Mux) HandleWord(word WordFunc) {
+	mux.handleMethod(GETWord, WordHandler(word))
+}
+
+// HandleStatus registers the status supplier for SHIORI/2.x GET Status requests, which have no event ID.
+// It panics if one is already registered.
+func (mux *Mux) HandleStatus(status StatusFunc) {
+	mux.handleMethod(GETStatus, StatusHandler(status))
+}
+
+func (mux *Mux) handleMethod(method Method, handler Handler) {
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+	if mux.methods == nil {
+		mux.methods = map[Method]Handler{}
+	}
+	if _, ok := mux.methods[method]; ok {
+		panic("shiori: multiple registrations for " + method.String())
+	}
+	mux.methods[method] = handler
+}
@@ -0,0 +1,76 @@
+package shiori
+
+import "testing"
+
+// messages captured from tools which emit slightly broken SHIORI messages
+var lenientCases = []struct {
+	name    string
+	request string
+	// strict reports whether the strict parser accepts the message too
+	strict bool
+}{
+	{"well-formed", "GET SHIORI/3.0\r\nCharset: UTF-8\r\nID: OnBoot\r\n\r\n", true},
+	{"missing final blank line", "GET SHIORI/3.0\r\nCharset: UTF-8\r\nID: OnBoot\r\n", true},
+	{"missing final CRLF", "GET SHIORI/3.0\r\nCharset: UTF-8\r\nID: OnBoot", true},
+	{"BOM", "\uFEFFGET SHIORI/3.0\r\nCharset: UTF-8\r\nID: OnBoot\r\n\r\n", false},
+	{"trailing space on request line", "GET SHIORI/3.0 \r\nCharset: UTF-8\r\nID: OnBoot\r\n\r\n", false},
+	{"trailing tab on request line", "GET SHIORI/3.0\t\r\nCharset: UTF-8\r\nID: OnBoot\r\n\r\n", false},
+	{"trailing spaces and tabs on headers", "GET SHIORI/3.0\r\nCharset: UTF-8 \t\r\nID: OnBoot  \r\n\r\n", true},
+	{"BOM, trailing space and no blank line", "\uFEFFGET SHIORI/3.0 \r\nCharset: UTF-8\r\nID: OnBoot \r\n", false},
+}
+
+func TestLenientParseRequest(t *testing.T) {
+	for _, test := range lenientCases {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := Parser{Lenient: true}.ParseRequest(test.request)
+			if err != nil {
+				t.Fatalf("lenient ParseRequest() error = %v", err)
+			}
+			if request.Method != GET || request.Version != "3.0" {
+				t.Errorf("request line = %v SHIORI/%s", request.Method, request.Version)
+			}
+			if request.Charset() != "UTF-8" || request.Headers[HeaderID] != "OnBoot" {
+				t.Errorf("headers = %q, want trimmed Charset and ID", request.Headers)
+			}
+		})
+	}
+}
+
+func TestStrictParseRequest(t *testing.T) {
+	for _, test := range lenientCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseRequest(test.request)
+			if test.strict && err != nil {
+				t.Errorf("ParseRequest() error = %v", err)
+			}
+			if !test.strict && err == nil {
+				t.Error("ParseRequest() accepted the broken message")
+			}
+		})
+	}
+}
+
+func TestLenientParseResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		strict   bool
+	}{
+		{"BOM", "\uFEFFSHIORI/3.0 200 OK\r\nValue: \\h\\e\r\n\r\n", false},
+		{"trailing whitespace and no blank line", "SHIORI/3.0 200 OK \r\nValue: \\h\\e\t\r\n", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := Parser{Lenient: true}.ParseResponse(test.response)
+			if err != nil {
+				t.Fatalf("lenient ParseResponse() error = %v", err)
+			}
+			if response.Code != 200 || response.Value(0) != "\\h\\e" {
+				t.Errorf("lenient ParseResponse() = %q", response.String())
+			}
+			if _, err := ParseResponse(test.response); (err == nil) != test.strict {
+				t.Errorf("ParseResponse() error = %v, strict acceptance want %v", err, test.strict)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package locale
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Catalog holds talk text by key per language, so that a multilingual ghost keeps one dictionary of keys.
+// Texts are fmt format strings taking the arguments of Text; use explicit argument indexes such as %[2]s where translations reorder them.
+type Catalog struct {
+	// Fallback is the language tried after the preference
+	Fallback string
+
+	mutex      sync.RWMutex
+	messages   map[string]map[string]string
+	preference []string
+}
+
+// NewCatalog makes an empty Catalog falling back to the language
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{Fallback: fallback, messages: map[string]map[string]string{}}
+}
+
+// Add adds the texts of the language by key (replacing existing ones of the same keys)
+func (catalog *Catalog) Add(language string, messages map[string]string) {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	if catalog.messages == nil {
+		catalog.messages = map[string]map[string]string{}
+	}
+	if catalog.messages[language] == nil {
+		catalog.messages[language] = map[string]string{}
+	}
+	for key, text := range messages {
+		catalog.messages[language][key] = text
+	}
+}
+
+// Load adds the texts of the language from JSON object of key to text
+func (catalog *Catalog) Load(language string, r io.Reader) error {
+	var messages map[string]string
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return err
+	}
+	catalog.Add(language, messages)
+	return nil
+}
+
+// SetPreference sets the languages tried in order before Fallback; it may be changed while serving
+func (catalog *Catalog) SetPreference(languages ...string) {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	catalog.preference = append([]string(nil), languages...)
+}
+
+// Preference returns the languages set by SetPreference
+func (catalog *Catalog) Preference() []string {
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+	return append([]string(nil), catalog.preference...)
+}
+
+// Text formats the text of the key in the first preferred language which has it
+func (catalog *Catalog) Text(key string, args ...any) (string, bool) {
+	texts := catalog.texts(key)
+	if len(texts) == 0 {
+		return "", false
+	}
+	return format(texts[0], args), true
+}
+
+// TextFor is like Text but skips translations not representable in the charset, such as Korean in Shift_JIS.
+// If no translation is representable, the first one is used anyway (see shiori.CharsetFallback).
+func (catalog *Catalog) TextFor(charset string, key string, args ...any) (string, bool) {
+	texts := catalog.texts(key)
+	if len(texts) == 0 {
+		return "", false
+	}
+	for _, text := range texts {
+		formatted := format(text, args)
+		if _, err := shiori.EncodeMessage(formatted, charset); err == nil {
+			return formatted, true
+		}
+	}
+	return format(texts[0], args), true
+}
+
+// Respond answers the request with the text of the key chosen by TextFor the request charset,
+// or 204 No Content if no language has the key
+func (catalog *Catalog) Respond(request shiori.Request, key string, args ...any) shiori.Response {
+	text, ok := catalog.TextFor(request.Charset(), key, args...)
+	if !ok {
+		return shiori.NoContent(shiori.WithDefaultsFrom(request))
+	}
+	return shiori.OK(text, shiori.WithDefaultsFrom(request))
+}
+
+// texts returns the texts of the key in the preferred languages then Fallback, in order
+func (catalog *Catalog) texts(key string) []string {
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+	var texts []string
+	for _, language := range catalog.preference {
+		if text, ok := catalog.messages[language][key]; ok {
+			texts = append(texts, text)
+		}
+	}
+	if text, ok := catalog.messages[catalog.Fallback][key]; ok {
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+func format(text string, args []any) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
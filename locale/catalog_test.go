@@ -0,0 +1,70 @@
+package locale
+
+import (
+	"strings"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func newTestCatalog(t *testing.T) *Catalog {
+	catalog := NewCatalog("en")
+	catalog.Add("en", map[string]string{"boot": "\\h\\s[0]Hello, %s.\\e", "close": "\\h\\s[0]Bye.\\-"})
+	if err := catalog.Load("ja", strings.NewReader(`{"boot": "\\h\\s[0]こんにちは、%sさん。\\e"}`)); err != nil {
+		t.Fatal(err)
+	}
+	catalog.Add("ko", map[string]string{"boot": "\\h\\s[0]안녕하세요, %s.\\e"})
+	return catalog
+}
+
+func TestCatalogText(t *testing.T) {
+	catalog := newTestCatalog(t)
+	tests := []struct {
+		name       string
+		preference []string
+		key        string
+		args       []any
+		want       string
+		wantOK     bool
+	}{
+		{"fallback", nil, "boot", []any{"Alice"}, "\\h\\s[0]Hello, Alice.\\e", true},
+		{"preferred", []string{"ja"}, "boot", []any{"Alice"}, "\\h\\s[0]こんにちは、Aliceさん。\\e", true},
+		{"missing translation", []string{"ja"}, "close", nil, "\\h\\s[0]Bye.\\-", true},
+		{"unknown language", []string{"fr", "ko"}, "boot", []any{"Alice"}, "\\h\\s[0]안녕하세요, Alice.\\e", true},
+		{"unknown key", []string{"ja"}, "nap", nil, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog.SetPreference(test.preference...)
+			if got, ok := catalog.Text(test.key, test.args...); got != test.want || ok != test.wantOK {
+				t.Errorf("Text() = %q, %v, want %q, %v", got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestCatalogRespond(t *testing.T) {
+	catalog := newTestCatalog(t)
+	catalog.SetPreference("ko", "ja")
+	tests := []struct {
+		charset   string
+		key       string
+		wantCode  int
+		wantValue string
+	}{
+		{"UTF-8", "boot", 200, "\\h\\s[0]안녕하세요, Alice.\\e"},
+		{"Shift_JIS", "boot", 200, "\\h\\s[0]こんにちは、Aliceさん。\\e"},
+		{"ISO-8859-1", "boot", 200, "\\h\\s[0]Hello, Alice.\\e"},
+		{"Shift_JIS", "nap", 204, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.charset+" "+test.key, func(t *testing.T) {
+			request := shiori.NewRequest(shiori.GET, "OnBoot")
+			request.Headers[shiori.HeaderCharset] = test.charset
+			response := catalog.Respond(request, test.key, "Alice")
+			if response.Code != test.wantCode || response.Value(0) != test.wantValue || response.Charset() != test.charset {
+				t.Errorf("Respond() = %q, want %d with Value %q in %s", response.String(), test.wantCode, test.wantValue, test.charset)
+			}
+		})
+	}
+}
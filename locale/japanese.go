@@ -0,0 +1,99 @@
+package locale
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Narazaka/shiorigo/clock"
+)
+
+// Japanese is Locale of Japanese conventions
+type Japanese struct {
+	// Era formats years in Japanese era (令和8年) instead of the Western calendar
+	Era bool
+}
+
+var japaneseWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// Number formats n with comma grouping, e.g. 1,234,567
+func (Japanese) Number(n int64) string {
+	return groupDigits(n, ",")
+}
+
+var japaneseUnits = [...]string{"", "万", "億", "兆", "京"}
+
+// LargeNumber formats n with 万/億/兆 units, e.g. 1億2345万6789
+func (Japanese) LargeNumber(n int64) string {
+	if n == 0 {
+		return "0"
+	}
+	sign := ""
+	if n < 0 {
+		sign = "-"
+	}
+	str := ""
+	for unit := 0; n != 0; unit++ {
+		part := n % 10000
+		if part < 0 {
+			part = -part
+		}
+		if part != 0 {
+			str = strconv.FormatInt(part, 10) + japaneseUnits[unit] + str
+		}
+		n /= 10000
+	}
+	return sign + str
+}
+
+// Date formats t like 2026年10月14日(水) (or 令和8年10月14日(水) with Era)
+func (japanese Japanese) Date(t time.Time) string {
+	year := ""
+	if japanese.Era {
+		// the era year is reckoned in JST, so the other fields must be too
+		t = t.In(clock.JST)
+		year = clock.FormatJapaneseYear(t)
+	}
+	if year == "" {
+		year = strconv.Itoa(t.Year()) + "年"
+	}
+	return year + strconv.Itoa(int(t.Month())) + "月" + strconv.Itoa(t.Day()) + "日(" + japaneseWeekdays[t.Weekday()] + ")"
+}
+
+// Ago formats d like たった今, 5分前, 3時間前, 3日前, 2か月前, 1年前
+func (Japanese) Ago(d time.Duration) string {
+	if amount := japaneseAmount(d); amount != "" {
+		return amount + "前"
+	}
+	return "たった今"
+}
+
+// Since formats d like 5分ぶり, 3日ぶり, 1年ぶり (empty under a minute)
+func (Japanese) Since(d time.Duration) string {
+	if amount := japaneseAmount(d); amount != "" {
+		return amount + "ぶり"
+	}
+	return ""
+}
+
+const (
+	day   = 24 * time.Hour
+	month = 30 * day
+	year  = 365 * day
+)
+
+func japaneseAmount(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return ""
+	case d < time.Hour:
+		return strconv.Itoa(int(d/time.Minute)) + "分"
+	case d < day:
+		return strconv.Itoa(int(d/time.Hour)) + "時間"
+	case d < month:
+		return strconv.Itoa(int(d/day)) + "日"
+	case d < year:
+		return strconv.Itoa(int(d/month)) + "か月"
+	default:
+		return strconv.Itoa(int(d/year)) + "年"
+	}
+}
@@ -0,0 +1,28 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapaneseDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		japanese Japanese
+		t        time.Time
+		want     string
+	}{
+		{"western", Japanese{}, time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC), "2026年10月14日(水)"},
+		{"western keeps zone", Japanese{}, time.Date(2019, time.April, 30, 16, 0, 0, 0, time.UTC), "2019年4月30日(火)"},
+		{"era", Japanese{Era: true}, time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC), "令和8年10月14日(水)"},
+		{"era boundary in JST", Japanese{Era: true}, time.Date(2019, time.April, 30, 16, 0, 0, 0, time.UTC), "令和元年5月1日(水)"},
+		{"new year in JST", Japanese{Era: true}, time.Date(2019, time.December, 31, 16, 0, 0, 0, time.UTC), "令和2年1月1日(水)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.japanese.Date(test.t); got != test.want {
+				t.Errorf("Date() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package locale formats numbers, dates and relative times for generated scripts, and resolves talk text from per-language catalogs.
+package locale
+
+import (
+	"sync"
+	"time"
+)
+
+// Locale formats values in a language's conventions
+type Locale interface {
+	// Number formats an integer, e.g. 1,234
+	Number(n int64) string
+	// Date formats the date of t
+	Date(t time.Time) string
+	// Ago formats elapsed time before now, e.g. 3日前
+	Ago(d time.Duration) string
+	// Since formats elapsed time since the last occasion, e.g. 3日ぶり
+	Since(d time.Duration) string
+}
+
+var (
+	localesMutex sync.RWMutex
+	locales      = map[string]Locale{
+		"ja": Japanese{},
+	}
+)
+
+// Register makes the locale available by Lookup
+func Register(name string, locale Locale) {
+	localesMutex.Lock()
+	defer localesMutex.Unlock()
+	locales[name] = locale
+}
+
+// Lookup returns the registered locale
+func Lookup(name string) (Locale, bool) {
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+	locale, ok := locales[name]
+	return locale, ok
+}
+
+// groupDigits formats n with separator every 3 digits
+func groupDigits(n int64, separator string) string {
+	negative := n < 0
+	var digits []byte
+	for i := 0; ; i++ {
+		if i != 0 && i%3 == 0 {
+			digits = append(digits, separator...)
+		}
+		digit := n % 10
+		if digit < 0 {
+			digit = -digit
+		}
+		digits = append(digits, byte('0'+digit))
+		n /= 10
+		if n == 0 {
+			break
+		}
+	}
+	if negative {
+		digits = append(digits, '-')
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
@@ -0,0 +1,213 @@
+package shiori
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Handler responds to a SHIORI request
+type Handler interface {
+	Serve(request Request) Response
+}
+
+// HandlerFunc is an ordinary function used as Handler
+type HandlerFunc func(request Request) Response
+
+// Serve calls handler(request)
+func (handler HandlerFunc) Serve(request Request) Response {
+	return handler(request)
+}
+
+// DispatchStep is a step of the Mux dispatch chain
+type DispatchStep int
+
+const (
+	// DispatchID looks up the handler registered for the event ID
+	DispatchID DispatchStep = iota
+	// DispatchBaseID looks up the handler registered for BaseID header (the event an extended event derives from)
+	DispatchBaseID
+	// DispatchWildcard looks up the handler registered for the longest matching wildcard such as "OnMouse*"
+	DispatchWildcard
+)
+
+// Reasons of 204 No Content responses in X-Shiori-NoContent-Reason header
+const (
+	// NoContentNoHandler is set by Mux when no handler is registered for the event
+	NoContentNoHandler = "no-handler"
+	// NoContentFiltered is for middleware which dropped the request
+	NoContentFiltered = "filtered"
+	// NoContentThrottled is for middleware which suppressed the response by rate limit or cooldown
+	NoContentThrottled = "throttled"
+)
+
+// WithNoContentReason sets X-Shiori-NoContent-Reason header of the response, which Mux removes unless Debug
+func WithNoContentReason(reason string) ResponseOption {
+	return WithHeader(HeaderNoContentReason, reason)
+}
+
+// DefaultDispatch is the dispatch chain of Mux whose Dispatch is nil
+var DefaultDispatch = []DispatchStep{DispatchID, DispatchBaseID, DispatchWildcard}
+
+// Mux dispatches requests to handlers registered per event ID (ID header, or Event header of SHIORI/2.x requests)
+type Mux struct {
+	// Aliases maps variant event IDs used by nonstandard basewares to canonical IDs.
+	// The event ID of aliased requests is rewritten before dispatch, so handlers only see canonical IDs.
+	// No aliases are built in, since guessed ones would misroute real events.
+	Aliases map[string]string
+	// Dispatch is the chain of lookups tried in order (DefaultDispatch when nil)
+	Dispatch []DispatchStep
+	// Fallback handles requests the whole chain misses (204 No Content when nil)
+	Fallback Handler
+	// Debug keeps X-Shiori-NoContent-Reason header of responses (removed otherwise)
+	// and sets it to NoContentNoHandler on 204 No Content for requests the whole chain misses
+	Debug bool
+
+	mutex    sync.RWMutex
+	handlers map[string]Handler
+	// wildcards are ordered by prefix length, longest first
+	wildcards []wildcardHandler
+	// methods holds handlers of SHIORI/2.x requests without event ID (GET Word, GET Status)
+	methods map[Method]Handler
+}
+
+type wildcardHandler struct {
+	prefix  string
+	handler Handler
+}
+
+// NewMux makes an empty Mux
+func NewMux() *Mux {
+	return &Mux{handlers: map[string]Handler{}}
+}
+
+// Handle registers the handler for the event ID.
+// An ID ending with "*" is a wildcard matching IDs with the prefix before it ("*" alone matches any event).
+// It panics if a handler is already registered for the ID.
+func (mux *Mux) Handle(id string, handler Handler) {
+	if handler == nil {
+		panic("shiori: nil handler for " + id)
+	}
+	mux.mutex.Lock()
+	defer mux.mutex.Unlock()
+	if strings.HasSuffix(id, "*") {
+		mux.handleWildcard(strings.TrimSuffix(id, "*"), handler)
+		return
+	}
+	if mux.handlers == nil {
+		mux.handlers = map[string]Handler{}
+	}
+	if _, ok := mux.handlers[id]; ok {
+		panic("shiori: multiple registrations for " + id)
+	}
+	mux.handlers[id] = handler
+}
+
+func (mux *Mux) handleWildcard(prefix string, handler Handler) {
+	for _, wildcard := range mux.wildcards {
+		if wildcard.prefix == prefix {
+			panic("shiori: multiple registrations for " + prefix + "*")
+		}
+	}
+	mux.wildcards = append(mux.wildcards, wildcardHandler{prefix: prefix, handler: handler})
+	sort.SliceStable(mux.wildcards, func(i, j int) bool {
+		return len(mux.wildcards[i].prefix) > len(mux.wildcards[j].prefix)
+	})
+}
+
+// HandleFunc registers the handler function for the event ID
+func (mux *Mux) HandleFunc(id string, handler func(request Request) Response) {
+	mux.Handle(id, HandlerFunc(handler))
+}
+
+// Handler returns the handler for the request and whether the dispatch chain found one
+// (a handler registered for the method of GET Word and GET Status comes first)
+func (mux *Mux) Handler(request Request) (handler Handler, ok bool) {
+	return mux.handler(mux.canonical(request))
+}
+
+func (mux *Mux) handler(request Request) (handler Handler, ok bool) {
+	mux.mutex.RLock()
+	handler, ok = mux.lookup(request)
+	mux.mutex.RUnlock()
+	if ok {
+		return handler, true
+	}
+	if mux.Fallback != nil {
+		return mux.Fallback, false
+	}
+	if mux.Debug {
+		return HandlerFunc(noHandler), false
+	}
+	return HandlerFunc(noContent), false
+}
+
+// lookup runs the dispatch chain; mux.mutex must be held
+func (mux *Mux) lookup(request Request) (Handler, bool) {
+	if handler, ok := mux.methods[request.Method]; ok {
+		return handler, true
+	}
+	dispatch := mux.Dispatch
+	if dispatch == nil {
+		dispatch = DefaultDispatch
+	}
+	id := request.EventID()
+	for _, step := range dispatch {
+		switch step {
+		case DispatchID:
+			if handler, ok := mux.handlers[id]; ok {
+				return handler, true
+			}
+		case DispatchBaseID:
+			if baseID, ok := request.Headers[HeaderBaseID]; ok {
+				if handler, ok := mux.handlers[baseID]; ok {
+					return handler, true
+				}
+			}
+		case DispatchWildcard:
+			for _, wildcard := range mux.wildcards {
+				if strings.HasPrefix(id, wildcard.prefix) {
+					return wildcard.handler, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Serve dispatches the request to the handler the dispatch chain finds, or Fallback
+func (mux *Mux) Serve(request Request) Response {
+	request = mux.canonical(request)
+	handler, _ := mux.handler(request)
+	response := handler.Serve(request)
+	if _, ok := response.Headers[HeaderNoContentReason]; ok && !mux.Debug {
+		// headers may be shared by the handler
+		response.Headers = ResponseHeaders(Headers(response.Headers).Clone())
+		delete(response.Headers, HeaderNoContentReason)
+	}
+	return response
+}
+
+// canonical rewrites the event ID of the request by Aliases
+func (mux *Mux) canonical(request Request) Request {
+	id := request.EventID()
+	canonical, ok := mux.Aliases[id]
+	if !ok || canonical == id {
+		return request
+	}
+	request = request.Clone()
+	if _, ok := request.Headers[HeaderID]; ok {
+		request.Headers[HeaderID] = canonical
+	} else {
+		request.Headers[HeaderEvent] = canonical
+	}
+	return request
+}
+
+func noContent(request Request) Response {
+	return NoContent(WithDefaultsFrom(request))
+}
+
+func noHandler(request Request) Response {
+	return NoContent(WithDefaultsFrom(request), WithNoContentReason(NoContentNoHandler))
+}
@@ -0,0 +1,179 @@
+package shiori
+
+import "testing"
+
+func newBenchMux() *Mux {
+	mux := NewMux()
+	for _, id := range []string{"OnBoot", "OnClose", "OnSecondChange", "OnMinuteChange", "OnMouseClick", "OnMouseDoubleClick"} {
+		mux.HandleFunc(id, func(request Request) Response {
+			return OK("\\h\\s[0]Hello.\\e", WithDefaultsFrom(request))
+		})
+	}
+	return mux
+}
+
+func TestMuxServe(t *testing.T) {
+	mux := newBenchMux()
+	if response := mux.Serve(benchRequest); response.Code != 200 || response.Charset() != "UTF-8" {
+		t.Errorf("registered event response = %q", response.String())
+	}
+	unknown := NewRequest(GET, "OnUnknown")
+	if response := mux.Serve(unknown); response.Code != 204 {
+		t.Errorf("unregistered event response = %q, want 204", response.String())
+	}
+	mux.Fallback = HandlerFunc(func(request Request) Response { return BadRequest("unknown") })
+	if response := mux.Serve(unknown); response.Code != 400 {
+		t.Errorf("fallback response = %q, want 400", response.String())
+	}
+}
+
+func BenchmarkMuxServe(b *testing.B) {
+	mux := newBenchMux()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = mux.Serve(benchRequest)
+	}
+}
+
+func TestMuxServeAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector adds allocations")
+	}
+	mux := newBenchMux()
+	// lookup allocates nothing; these are the response the handler builds
+	const budget = 3
+	if allocs := testing.AllocsPerRun(100, func() { mux.Serve(benchRequest) }); allocs > budget {
+		t.Errorf("Mux.Serve allocates %v times, budget %v", allocs, budget)
+	}
+}
+
+func TestMuxLegacyHandlers(t *testing.T) {
+	mux := NewMux()
+	mux.HandleWord(func(wordType string, request Request) string {
+		if wordType == "\\ms" {
+			return "apple"
+		}
+		return ""
+	})
+	mux.HandleStatus(func(request Request) string { return "3,1" })
+	tests := []struct {
+		name       string
+		request    string
+		wantCode   int
+		wantHeader string
+		wantValue  string
+	}{
+		{"word", "GET Word SHIORI/2.5\r\nCharset: Shift_JIS\r\nType: \\ms\r\n\r\n", 200, HeaderWord, "apple"},
+		{"unknown word type", "GET Word SHIORI/2.5\r\nType: \\mz\r\n\r\n", 204, HeaderWord, ""},
+		{"status", "GET Status SHIORI/2.5\r\n\r\n", 200, HeaderStatus, "3,1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := MustParseRequest(test.request)
+			response := mux.Serve(request)
+			if response.Code != test.wantCode || response.Version != "2.5" || response.Headers[test.wantHeader] != test.wantValue {
+				t.Errorf("response = %q", response.String())
+			}
+			if response.Charset() != request.Charset() {
+				t.Errorf("response charset = %q, want %q", response.Charset(), request.Charset())
+			}
+		})
+	}
+}
+
+func TestMuxDispatch(t *testing.T) {
+	handler := func(name string) HandlerFunc {
+		return func(request Request) Response { return OK(name) }
+	}
+	mux := NewMux()
+	mux.Handle("OnMouseClick", handler("id"))
+	mux.Handle("OnMouse*", handler("OnMouse*"))
+	mux.Handle("On*", handler("On*"))
+	tests := []struct {
+		name     string
+		dispatch []DispatchStep
+		request  string
+		want     string
+	}{
+		{"exact ID", nil, "GET SHIORI/3.0\r\nID: OnMouseClick\r\n\r\n", "id"},
+		{"BaseID", nil, "GET SHIORI/3.0\r\nID: OnMouseClickEx\r\nBaseID: OnMouseClick\r\n\r\n", "id"},
+		{"longest wildcard", nil, "GET SHIORI/3.0\r\nID: OnMouseMove\r\n\r\n", "OnMouse*"},
+		{"shorter wildcard", nil, "GET SHIORI/3.0\r\nID: OnBoot\r\n\r\n", "On*"},
+		{"miss", nil, "GET SHIORI/3.0\r\nID: version\r\n\r\n", ""},
+		{"without BaseID step", []DispatchStep{DispatchID, DispatchWildcard}, "GET SHIORI/3.0\r\nID: OnMouseClickEx\r\nBaseID: OnMouseClick\r\n\r\n", "OnMouse*"},
+		{"wildcard first", []DispatchStep{DispatchWildcard, DispatchID}, "GET SHIORI/3.0\r\nID: OnMouseClick\r\n\r\n", "OnMouse*"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mux.Dispatch = test.dispatch
+			response := mux.Serve(MustParseRequest(test.request))
+			if test.want == "" {
+				if response.Code != 204 {
+					t.Errorf("response = %q, want 204", response.String())
+				}
+				return
+			}
+			if response.Value(0) != test.want {
+				t.Errorf("handled by %q, want %q", response.Value(0), test.want)
+			}
+		})
+	}
+}
+
+func TestMuxNoContentReason(t *testing.T) {
+	mux := NewMux()
+	mux.HandleFunc("OnSecondChange", func(request Request) Response {
+		return NoContent(WithDefaultsFrom(request), WithNoContentReason(NoContentThrottled))
+	})
+	tests := []struct {
+		name  string
+		debug bool
+		id    string
+		want  string
+	}{
+		{"no handler", true, "OnBoot", NoContentNoHandler},
+		{"middleware", true, "OnSecondChange", NoContentThrottled},
+		{"no handler without debug", false, "OnBoot", ""},
+		{"middleware without debug", false, "OnSecondChange", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mux.Debug = test.debug
+			response := mux.Serve(NewRequest(GET, test.id))
+			if reason, ok := response.Headers[HeaderNoContentReason]; response.Code != 204 || reason != test.want || ok != (test.want != "") {
+				t.Errorf("response = %q, want reason %q", response.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestMuxAliases(t *testing.T) {
+	mux := NewMux()
+	mux.Aliases = map[string]string{"OnMouseDblClick": "OnMouseDoubleClick"}
+	mux.HandleFunc("OnMouseDoubleClick", func(request Request) Response {
+		return OK(request.EventID())
+	})
+	tests := []struct {
+		name    string
+		request string
+	}{
+		{"canonical", "GET SHIORI/3.0\r\nID: OnMouseDoubleClick\r\n\r\n"},
+		{"alias", "GET SHIORI/3.0\r\nID: OnMouseDblClick\r\n\r\n"},
+		{"SHIORI/2.x alias", "GET Sentence SHIORI/2.6\r\nEvent: OnMouseDblClick\r\n\r\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := MustParseRequest(test.request)
+			id := request.EventID()
+			if _, ok := mux.Handler(request); !ok {
+				t.Error("Handler() found no handler")
+			}
+			if response := mux.Serve(request); response.Value(0) != "OnMouseDoubleClick" {
+				t.Errorf("handler saw ID %q, want OnMouseDoubleClick", response.Value(0))
+			}
+			if request.EventID() != id {
+				t.Error("Serve() modified the request")
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package shiori
+
+const raceEnabled = false
@@ -0,0 +1,6 @@
+//go:build race
+
+package shiori
+
+// raceEnabled reports whether tests run under the race detector, which adds allocations
+const raceEnabled = true
@@ -0,0 +1,90 @@
+package shiori
+
+import "strings"
+
+// DefaultVersion is SHIORI version used by Response constructors
+const DefaultVersion = "3.0"
+
+// ResponseOption customizes Response made by constructors such as OK
+type ResponseOption func(*Response)
+
+// WithDefaultsFrom fills Version and Charset of the response from the request.
+//
+// Sender is not copied: on a Response it names the SHIORI, not the baseware. Use WithSender.
+func WithDefaultsFrom(request Request) ResponseOption {
+	return func(response *Response) {
+		if request.Version != "" {
+			response.Version = request.Version
+		}
+		if charset := request.Charset(); charset != "" {
+			response.Headers[HeaderCharset] = charset
+		}
+	}
+}
+
+// WithSender sets Sender header of the response
+func WithSender(sender string) ResponseOption {
+	return func(response *Response) {
+		response.Headers[HeaderSender] = sender
+	}
+}
+
+// WithHeader sets arbitrary header of the response
+func WithHeader(name string, value string) ResponseOption {
+	return func(response *Response) {
+		response.Headers[name] = value
+	}
+}
+
+// NewResponse makes Response with the code and options applied
+func NewResponse(code int, options ...ResponseOption) Response {
+	return newResponse(code, ResponseHeaders{}, options)
+}
+
+func newResponse(code int, headers ResponseHeaders, options []ResponseOption) Response {
+	response := Response{
+		Code:     code,
+		Protocol: SHIORI,
+		Version:  DefaultVersion,
+		Headers:  headers,
+	}
+	for _, option := range options {
+		option(&response)
+	}
+	return response
+}
+
+// OK makes 200 OK Response with Value header
+func OK(script string, options ...ResponseOption) Response {
+	return newResponse(200, ResponseHeaders{HeaderValue: script}, options)
+}
+
+// NoContent makes 204 No Content Response
+func NoContent(options ...ResponseOption) Response {
+	return newResponse(204, ResponseHeaders{}, options)
+}
+
+// BadRequest makes 400 Bad Request Response with ErrorDescription header
+func BadRequest(description string, options ...ResponseOption) Response {
+	headers := ResponseHeaders{
+		HeaderErrorLevel:       "error",
+		HeaderErrorDescription: singleLine(description),
+	}
+	return newResponse(400, headers, options)
+}
+
+// InternalError makes 500 Internal Server Error Response with ErrorDescription header
+func InternalError(err error, options ...ResponseOption) Response {
+	headers := ResponseHeaders{HeaderErrorLevel: "critical"}
+	if err != nil {
+		headers[HeaderErrorDescription] = singleLine(err.Error())
+	}
+	return newResponse(500, headers, options)
+}
+
+var singleLineReplacer = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// singleLine makes str safe as a header value
+func singleLine(str string) string {
+	return singleLineReplacer.Replace(str)
+}
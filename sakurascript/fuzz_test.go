@@ -0,0 +1,58 @@
+package sakurascript
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// splitArguments parses arguments of \name[arg,...] tag, the inverse of Tag
+func splitArguments(tag string) ([]string, bool) {
+	start := strings.IndexByte(tag, '[')
+	if start < 0 || !strings.HasSuffix(tag, "]") {
+		return nil, false
+	}
+	var args []string
+	var arg strings.Builder
+	quoted := false
+	body := tag[start+1 : len(tag)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case quoted && c == '"' && i+1 < len(body) && body[i+1] == '"':
+			arg.WriteByte('"')
+			i++
+		case c == '"' && (quoted || arg.Len() == 0):
+			quoted = !quoted
+		case c == ',' && !quoted:
+			args = append(args, arg.String())
+			arg.Reset()
+		case (c == ']' || c == '"') && !quoted:
+			return nil, false
+		default:
+			arg.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, false
+	}
+	return append(args, arg.String()), true
+}
+
+func FuzzSakuraScript(f *testing.F) {
+	f.Add("ghost", "sakura")
+	f.Add("a,b", "\"quoted\"")
+	f.Add(" padded ", "]")
+	f.Add("", "")
+	f.Fuzz(func(t *testing.T, first string, second string) {
+		args := []string{first, second}
+		tag := Command("change", args...)
+		got, ok := splitArguments(tag)
+		if !ok {
+			t.Fatalf("Command() = %q is not a well-formed tag", tag)
+		}
+		if want := append([]string{"change"}, args...); !reflect.DeepEqual(got, want) {
+			t.Errorf("arguments of %q = %q, want %q", tag, got, want)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package sakurascript
+
+// ChangeGhost builds \![change,ghost,name] which switches to the ghost.
+// Name may be "random" or "sequential" as well as a ghost (or \0) name.
+func ChangeGhost(name string, options ...string) string {
+	return Command("change", append([]string{"ghost", name}, options...)...)
+}
+
+// CallGhost builds \![call,ghost,name] which boots the ghost alongside.
+// Name may be "random" as well as a ghost (or \0) name.
+func CallGhost(name string, options ...string) string {
+	return Command("call", append([]string{"ghost", name}, options...)...)
+}
@@ -0,0 +1,29 @@
+// Package sakurascript builds SakuraScript tags for responses.
+package sakurascript
+
+import "strings"
+
+// argument quotes a tag argument if it contains characters which would break the tag
+func argument(arg string) string {
+	if !strings.ContainsAny(arg, ",\"]") && strings.TrimSpace(arg) == arg {
+		return arg
+	}
+	return "\"" + strings.ReplaceAll(arg, "\"", "\"\"") + "\""
+}
+
+// Tag builds \name[arg,...] tag (or \name when no arguments)
+func Tag(name string, args ...string) string {
+	if len(args) == 0 {
+		return "\\" + name
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = argument(arg)
+	}
+	return "\\" + name + "[" + strings.Join(quoted, ",") + "]"
+}
+
+// Command builds \![command,arg,...] tag
+func Command(command string, args ...string) string {
+	return Tag("!", append([]string{command}, args...)...)
+}
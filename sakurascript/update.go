@@ -0,0 +1,7 @@
+package sakurascript
+
+// UpdateByMyself builds \![updatebymyself] which starts network update of the ghost itself.
+// Feed the following requests to events.UpdateTracker to follow its progress.
+func UpdateByMyself() string {
+	return Command("updatebymyself")
+}
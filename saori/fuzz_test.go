@@ -0,0 +1,43 @@
+package saori_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Narazaka/shiorigo/saori"
+	"github.com/Narazaka/shiorigo/shioritest"
+)
+
+func FuzzParseSAORI(f *testing.F) {
+	shioritest.AddSeeds(f, shioritest.SAORISeeds()...)
+	f.Fuzz(func(t *testing.T, message string) {
+		saori.DecodeRequest([]byte(message))
+		saori.DecodeResponse([]byte(message))
+		if strings.HasPrefix(message, "SAORI/") {
+			response, err := saori.ParseResponse(message)
+			if err != nil {
+				return
+			}
+			reparsed, err := saori.ParseResponse(response.String())
+			if err != nil {
+				t.Fatalf("ParseResponse(%q) of serialized response error = %v", response.String(), err)
+			}
+			if !reflect.DeepEqual(reparsed, response) {
+				t.Errorf("round trip = %q, want %q", reparsed.String(), response.String())
+			}
+			return
+		}
+		request, err := saori.ParseRequest(message)
+		if err != nil {
+			return
+		}
+		reparsed, err := saori.ParseRequest(request.String())
+		if err != nil {
+			t.Fatalf("ParseRequest(%q) of serialized request error = %v", request.String(), err)
+		}
+		if !reflect.DeepEqual(reparsed, request) {
+			t.Errorf("round trip = %q, want %q", reparsed.String(), request.String())
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package saori
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+var (
+	requestLineRe = regexp.MustCompile(`^(.+) SAORI/(\d+\.\d+)$`)
+	// the reason phrase may be empty or absent, as String writes no phrase for unknown codes
+	statusLineRe = regexp.MustCompile(`^SAORI/(\d+\.\d+) (\d+)(?: (.*))?$`)
+)
+
+// ParseRequestError is Request parsing error
+type ParseRequestError string
+
+func (err ParseRequestError) Error() string {
+	return "ParseRequestError: " + string(err)
+}
+
+// Is reports ParseRequestError matches shiori.ErrParse
+func (err ParseRequestError) Is(target error) bool {
+	return target == shiori.ErrParse
+}
+
+// ParseResponseError is Response parsing error
+type ParseResponseError string
+
+func (err ParseResponseError) Error() string {
+	return "ParseResponseError: " + string(err)
+}
+
+// Is reports ParseResponseError matches shiori.ErrParse
+func (err ParseResponseError) Is(target error) bool {
+	return target == shiori.ErrParse
+}
+
+// ParseRequest converts SAORI/1.0 Request Message into Request type
+func ParseRequest(requestStr string) (Request, error) {
+	request := Request{}
+	lines := strings.Split(requestStr, "\r\n")
+	requestLineResult := requestLineRe.FindStringSubmatch(lines[0])
+	if requestLineResult == nil {
+		return request, ParseRequestError("request line parse failed: " + lines[0])
+	}
+	var err error
+	request.Method, err = ToMethod(requestLineResult[1])
+	if err != nil {
+		return request, err
+	}
+	request.Version = requestLineResult[2]
+	request.Headers, err = shiori.ParseHeaderLines(lines[1:])
+	return request, err
+}
+
+// ParseResponse converts SAORI/1.0 Response Message into Response type
+func ParseResponse(responseStr string) (Response, error) {
+	response := Response{}
+	lines := strings.Split(responseStr, "\r\n")
+	statusLineResult := statusLineRe.FindStringSubmatch(lines[0])
+	if statusLineResult == nil {
+		return response, ParseResponseError("status line parse failed: " + lines[0])
+	}
+	response.Version = statusLineResult[1]
+	var err error
+	response.Code, err = strconv.Atoi(statusLineResult[2])
+	if err != nil {
+		return response, ParseResponseError("status code parse failed: " + lines[0])
+	}
+	response.Headers, err = shiori.ParseHeaderLines(lines[1:])
+	return response, err
+}
+
+// DecodeRequest converts raw SAORI/1.0 Request Message in the charset declared by its Charset header into Request type
+func DecodeRequest(data []byte) (Request, error) {
+	message, err := shiori.DecodeMessage(data)
+	if err != nil {
+		return Request{}, err
+	}
+	return ParseRequest(message)
+}
+
+// DecodeResponse converts raw SAORI/1.0 Response Message in the charset declared by its Charset header into Response type
+func DecodeResponse(data []byte) (Response, error) {
+	message, err := shiori.DecodeMessage(data)
+	if err != nil {
+		return Response{}, err
+	}
+	return ParseResponse(message)
+}
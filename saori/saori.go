@@ -0,0 +1,219 @@
+// Package saori implements SAORI/1.0 messages (GET Version, EXECUTE) for writing SAORI modules.
+//
+// SAORI messages have the same shape as SHIORI messages, so shiori.Headers helpers work on their headers.
+package saori
+
+import (
+	"strconv"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Method is SAORI Request Method
+type Method int
+
+const (
+	// InvalidMethod is reserved value for error case
+	InvalidMethod Method = iota
+	// GETVersion is GET Version SAORI/1.0
+	GETVersion
+	// EXECUTE is EXECUTE SAORI/1.0
+	EXECUTE
+)
+
+var methodNames = map[Method]string{
+	GETVersion: "GET Version",
+	EXECUTE:    "EXECUTE",
+}
+
+func (method Method) String() string {
+	return methodNames[method]
+}
+
+// DefaultVersion is SAORI version used by constructors
+const DefaultVersion = "1.0"
+
+// Standard SAORI/1.0 header names.
+const (
+	// HeaderCharset is Charset header
+	HeaderCharset = shiori.HeaderCharset
+	// HeaderSender is Sender header
+	HeaderSender = shiori.HeaderSender
+	// HeaderSecurityLevel is SecurityLevel header
+	HeaderSecurityLevel = shiori.HeaderSecurityLevel
+	// HeaderArgumentPrefix is prefix of Argument* headers
+	HeaderArgumentPrefix = "Argument"
+	// HeaderResult is Result header
+	HeaderResult = "Result"
+	// HeaderValuePrefix is prefix of Value* headers
+	HeaderValuePrefix = "Value"
+)
+
+// InvalidMethodError is invalid method error
+type InvalidMethodError string
+
+func (err InvalidMethodError) Error() string {
+	return "InvalidMethodError: " + string(err)
+}
+
+// Is reports InvalidMethodError matches shiori.ErrInvalidMethod and shiori.ErrParse
+func (err InvalidMethodError) Is(target error) bool {
+	return target == shiori.ErrInvalidMethod || target == shiori.ErrParse
+}
+
+// ToMethod converts method string into Method type
+func ToMethod(method string) (Method, error) {
+	for value, name := range methodNames {
+		if name == method {
+			return value, nil
+		}
+	}
+	return InvalidMethod, InvalidMethodError(method)
+}
+
+// Request is SAORI/1.0 Request Message
+type Request struct {
+	Method  Method
+	Version string
+	Headers shiori.Headers
+}
+
+// NewRequest makes Request with Argument0..N headers
+func NewRequest(method Method, arguments ...string) Request {
+	headers := shiori.Headers{}
+	for i, argument := range arguments {
+		headers[HeaderArgumentPrefix+strconv.Itoa(i)] = argument
+	}
+	return Request{Method: method, Version: DefaultVersion, Headers: headers}
+}
+
+// Charset header
+func (request *Request) Charset() string {
+	return (*request).Headers[HeaderCharset]
+}
+
+// Sender header
+func (request *Request) Sender() string {
+	return (*request).Headers[HeaderSender]
+}
+
+// SecurityLevel header
+func (request *Request) SecurityLevel() string {
+	return (*request).Headers[HeaderSecurityLevel]
+}
+
+// Argument gets Argument* header
+func (request *Request) Argument(i int) string {
+	return (*request).Headers[HeaderArgumentPrefix+strconv.Itoa(i)]
+}
+
+// Arguments gets Argument0..N headers up to the first absent index
+func (request *Request) Arguments() []string {
+	return numbered((*request).Headers, HeaderArgumentPrefix)
+}
+
+func (request Request) String() string {
+	var builder strings.Builder
+	builder.WriteString(request.Method.String())
+	builder.WriteString(" SAORI/")
+	builder.WriteString(request.Version)
+	builder.WriteString("\r\n")
+	request.Headers.WriteSorted(&builder)
+	builder.WriteString("\r\n")
+	return builder.String()
+}
+
+// Encode converts the request into SAORI/1.0 Request Message in the charset declared by its Charset header (UTF-8 if none)
+func (request Request) Encode() ([]byte, error) {
+	return shiori.EncodeMessage(request.String(), request.Charset())
+}
+
+// Response is SAORI/1.0 Response Message
+type Response struct {
+	Version string
+	Code    int
+	Headers shiori.Headers
+}
+
+// NewResponse makes Response of the code with DefaultVersion
+func NewResponse(code int) Response {
+	return Response{Version: DefaultVersion, Code: code, Headers: shiori.Headers{}}
+}
+
+// OK makes 200 OK Response with Result and Value0..N headers
+func OK(result string, values ...string) Response {
+	response := NewResponse(200)
+	response.Headers[HeaderResult] = result
+	for i, value := range values {
+		response.Headers[HeaderValuePrefix+strconv.Itoa(i)] = value
+	}
+	return response
+}
+
+// Message gets the reason phrase of the status code
+func (response *Response) Message() string {
+	switch (*response).Code {
+	case 200:
+		return "OK"
+	case 204:
+		return "No Content"
+	case 400:
+		return "Bad Request"
+	case 500:
+		return "Internal Server Error"
+	default:
+		return ""
+	}
+}
+
+// Charset header
+func (response *Response) Charset() string {
+	return (*response).Headers[HeaderCharset]
+}
+
+// Result header
+func (response *Response) Result() string {
+	return (*response).Headers[HeaderResult]
+}
+
+// Value gets Value* header
+func (response *Response) Value(i int) string {
+	return (*response).Headers[HeaderValuePrefix+strconv.Itoa(i)]
+}
+
+// Values gets Value0..N headers up to the first absent index
+func (response *Response) Values() []string {
+	return numbered((*response).Headers, HeaderValuePrefix)
+}
+
+func (response Response) String() string {
+	var builder strings.Builder
+	builder.WriteString("SAORI/")
+	builder.WriteString(response.Version)
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(response.Code))
+	builder.WriteByte(' ')
+	builder.WriteString(response.Message())
+	builder.WriteString("\r\n")
+	response.Headers.WriteSorted(&builder)
+	builder.WriteString("\r\n")
+	return builder.String()
+}
+
+// Encode converts the response into SAORI/1.0 Response Message in the charset declared by its Charset header (UTF-8 if none)
+func (response Response) Encode() ([]byte, error) {
+	return shiori.EncodeMessage(response.String(), response.Charset())
+}
+
+// numbered gets prefix0..N headers up to the first absent index
+func numbered(headers shiori.Headers, prefix string) []string {
+	var values []string
+	for i := 0; ; i++ {
+		value, ok := headers[prefix+strconv.Itoa(i)]
+		if !ok {
+			return values
+		}
+		values = append(values, value)
+	}
+}
@@ -0,0 +1,49 @@
+package saori
+
+import "testing"
+
+func TestResponseString(t *testing.T) {
+	response := OK("r", "v0", "v1", "v2", "v3", "v4", "v5", "v6", "v7", "v8", "v9", "v10")
+	response.Headers[HeaderCharset] = "UTF-8"
+	want := "SAORI/1.0 200 OK\r\nCharset: UTF-8\r\nResult: r\r\n" +
+		"Value0: v0\r\nValue1: v1\r\nValue2: v2\r\nValue3: v3\r\nValue4: v4\r\nValue5: v5\r\n" +
+		"Value6: v6\r\nValue7: v7\r\nValue8: v8\r\nValue9: v9\r\nValue10: v10\r\n\r\n"
+	if got := response.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRequestRoundTrip(t *testing.T) {
+	request := NewRequest(EXECUTE, "a", "b")
+	request.Headers[HeaderCharset] = "Shift_JIS"
+	request.Headers[HeaderArgumentPrefix+"2"] = "表"
+	data, err := request.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := DecodeRequest(data)
+	if err != nil {
+		t.Fatalf("DecodeRequest() error = %v", err)
+	}
+	if arguments := decoded.Arguments(); len(arguments) != 3 || arguments[2] != "表" {
+		t.Errorf("Arguments() = %q", arguments)
+	}
+	if decoded.String() != request.String() {
+		t.Errorf("round trip = %q, want %q", decoded.String(), request.String())
+	}
+}
+
+func TestUnknownCodeRoundTrip(t *testing.T) {
+	for _, code := range []int{200, 404, 999} {
+		response := NewResponse(code)
+		response.Headers[HeaderResult] = "r"
+		parsed, err := ParseResponse(response.String())
+		if err != nil {
+			t.Errorf("ParseResponse(%q) error = %v", response.String(), err)
+			continue
+		}
+		if parsed.Code != code || parsed.String() != response.String() {
+			t.Errorf("round trip = %q, want %q", parsed.String(), response.String())
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package shiori
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+var messageTerminator = []byte("\r\n\r\n")
+
+// ScanMessages is bufio.SplitFunc that splits a stream into SHIORI messages.
+// Each token is one message including its terminating blank line;
+// blank lines between messages are skipped and a trailing message without the blank line is returned at EOF.
+func ScanMessages(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for bytes.HasPrefix(data[advance:], []byte("\r\n")) {
+		advance += 2
+	}
+	rest := data[advance:]
+	if index := bytes.Index(rest, messageTerminator); index >= 0 {
+		end := index + len(messageTerminator)
+		return advance + end, rest[:end], nil
+	}
+	if atEOF {
+		if len(rest) == 0 || (len(rest) == 1 && rest[0] == '\r') {
+			return len(data), nil, nil
+		}
+		return len(data), rest, nil
+	}
+	// request more data (keeping skipped blank lines consumed)
+	return advance, nil, nil
+}
+
+// NewScanner makes bufio.Scanner which iterates SHIORI messages in r.
+// Use Buffer on the result to read messages larger than bufio.MaxScanTokenSize.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(ScanMessages)
+	return scanner
+}
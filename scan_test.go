@@ -0,0 +1,50 @@
+package shiori
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScanMessages(t *testing.T) {
+	const boot = "GET SHIORI/3.0\r\nID: OnBoot\r\n\r\n"
+	const ok = "SHIORI/3.0 200 OK\r\nValue: \\h\\s[0]Hello.\\e\r\n\r\n"
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"concatenated", boot + ok, []string{boot, ok}},
+		{"blank lines between messages", "\r\n" + boot + "\r\n\r\n" + ok + "\r\n", []string{boot, ok}},
+		{"trailing message without terminator", boot + "GET SHIORI/3.0\r\nID: OnClose\r\n", []string{boot, "GET SHIORI/3.0\r\nID: OnClose\r\n"}},
+		{"lone CR at EOF", boot + "\r", []string{boot}},
+		{"only blank lines", "\r\n\r\n\r\n", nil},
+	}
+	readers := []struct {
+		name string
+		wrap func(r io.Reader) io.Reader
+	}{
+		{"whole", func(r io.Reader) io.Reader { return r }},
+		{"one byte per read", iotest.OneByteReader},
+	}
+	for _, test := range tests {
+		for _, reader := range readers {
+			t.Run(test.name+"/"+reader.name, func(t *testing.T) {
+				scanner := NewScanner(reader.wrap(strings.NewReader(test.input)))
+				var got []string
+				for scanner.Scan() {
+					got = append(got, scanner.Text())
+				}
+				if err := scanner.Err(); err != nil {
+					t.Fatalf("Err() = %v", err)
+				}
+				if !reflect.DeepEqual(got, test.want) {
+					t.Errorf("messages = %q, want %q", got, test.want)
+				}
+			})
+		}
+	}
+}
@@ -1,10 +1,11 @@
 package shiori
 
 import (
-	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Method is SHIORI Request Method
@@ -17,17 +18,47 @@ const (
 	GET
 	// NOTIFY is NOTIFY SHIORI/x.x
 	NOTIFY
+	// GETVersion is GET Version SHIORI/2.x
+	GETVersion
+	// GETSentence is GET Sentence SHIORI/2.x
+	GETSentence
+	// GETWord is GET Word SHIORI/2.x
+	GETWord
+	// GETStatus is GET Status SHIORI/2.x
+	GETStatus
+	// TEACH is TEACH SHIORI/2.x
+	TEACH
+	// GETString is GET String SHIORI/2.x
+	GETString
+	// NOTIFYOwnerGhostName is NOTIFY OwnerGhostName SHIORI/2.x
+	NOTIFYOwnerGhostName
+	// NOTIFYOtherGhostName is NOTIFY OtherGhostName SHIORI/2.x
+	NOTIFYOtherGhostName
+	// TRANSLATESentence is TRANSLATE Sentence SHIORI/2.x
+	TRANSLATESentence
 )
 
+var methodNames = map[Method]string{
+	GET:                  "GET",
+	NOTIFY:               "NOTIFY",
+	GETVersion:           "GET Version",
+	GETSentence:          "GET Sentence",
+	GETWord:              "GET Word",
+	GETStatus:            "GET Status",
+	TEACH:                "TEACH",
+	GETString:            "GET String",
+	NOTIFYOwnerGhostName: "NOTIFY OwnerGhostName",
+	NOTIFYOtherGhostName: "NOTIFY OtherGhostName",
+	TRANSLATESentence:    "TRANSLATE Sentence",
+}
+
 func (method Method) String() string {
-	switch method {
-	case GET:
-		return "GET"
-	case NOTIFY:
-		return "NOTIFY"
-	default:
-		return ""
-	}
+	return methodNames[method]
+}
+
+// IsV2 reports whether the method is SHIORI/2.x form
+func (method Method) IsV2() bool {
+	return method >= GETVersion
 }
 
 // InvalidMethodError is invalid method error
@@ -39,14 +70,12 @@ func (err InvalidMethodError) Error() string {
 
 // ToMethod converts method string into Method type
 func ToMethod(method string) (Method, error) {
-	switch method {
-	case "GET":
-		return GET, nil
-	case "NOTIFY":
-		return NOTIFY, nil
-	default:
-		return InvalidMethod, InvalidMethodError(method)
+	for value, name := range methodNames {
+		if name == method {
+			return value, nil
+		}
 	}
+	return InvalidMethod, InvalidMethodError(method)
 }
 
 // Protocol is SHIORI
@@ -71,23 +100,59 @@ type Request struct {
 	Headers  RequestHeaders
 }
 
+// IsV2 reports whether the request is SHIORI/2.x
+func (request *Request) IsV2() bool {
+	return strings.HasPrefix((*request).Version, "2.")
+}
+
+// EventID gets the event name: ID header, or Event header of SHIORI/2.x requests
+func (request *Request) EventID() string {
+	if id, ok := (*request).Headers[HeaderID]; ok {
+		return id
+	}
+	return (*request).Headers[HeaderEvent]
+}
+
 // Charset header
 func (request *Request) Charset() string {
-	return (*request).Headers["Charset"]
+	return (*request).Headers[HeaderCharset]
 }
 
 // Sender header
 func (request *Request) Sender() string {
-	return (*request).Headers["Sender"]
+	return (*request).Headers[HeaderSender]
 }
 
 // Reference gets Reference* header
 func (request *Request) Reference(i int) string {
-	return (*request).Headers["Reference"+strconv.Itoa(i)]
+	return (*request).Headers[HeaderReferencePrefix+strconv.Itoa(i)]
+}
+
+// ReferenceOK gets Reference* header and whether it exists (distinguishes absent from empty)
+func (request *Request) ReferenceOK(i int) (string, bool) {
+	value, ok := (*request).Headers[HeaderReferencePrefix+strconv.Itoa(i)]
+	return value, ok
+}
+
+// References gets all Reference* headers by index; absent indexes are absent from the map
+func (request *Request) References() map[int]string {
+	return Headers((*request).Headers).References()
 }
 
 func (request Request) String() string {
-	return fmt.Sprintf("%s %s/%s\r\n%s\r\n", request.Method, request.Protocol, request.Version, request.Headers)
+	var builder strings.Builder
+	method := request.Method.String()
+	protocol := request.Protocol.String()
+	builder.Grow(len(method) + 1 + len(protocol) + 1 + len(request.Version) + 2 + Headers(request.Headers).size() + 2)
+	builder.WriteString(method)
+	builder.WriteByte(' ')
+	builder.WriteString(protocol)
+	builder.WriteByte('/')
+	builder.WriteString(request.Version)
+	builder.WriteString("\r\n")
+	Headers(request.Headers).writeTo(&builder)
+	builder.WriteString("\r\n")
+	return builder.String()
 }
 
 // Response is SHIORI/x.x Response Message
@@ -120,28 +185,84 @@ func (response *Response) Message() string {
 	}
 }
 
+// IsV2 reports whether the response is SHIORI/2.x
+func (response *Response) IsV2() bool {
+	return strings.HasPrefix((*response).Version, "2.")
+}
+
+// Script gets the returned script: Value header, or Sentence header of SHIORI/2.x responses
+func (response *Response) Script() string {
+	if (*response).IsV2() {
+		if sentence, ok := (*response).Headers[HeaderSentence]; ok {
+			return sentence
+		}
+	}
+	return (*response).Headers[HeaderValue]
+}
+
+// Sentence header (SHIORI/2.x)
+func (response *Response) Sentence() string {
+	return (*response).Headers[HeaderSentence]
+}
+
+// Word header (SHIORI/2.x)
+func (response *Response) Word() string {
+	return (*response).Headers[HeaderWord]
+}
+
+// Status header (SHIORI/2.x)
+func (response *Response) Status() string {
+	return (*response).Headers[HeaderStatus]
+}
+
 // Charset header
 func (response *Response) Charset() string {
-	return (*response).Headers["Charset"]
+	return (*response).Headers[HeaderCharset]
 }
 
 // Sender header
 func (response *Response) Sender() string {
-	return (*response).Headers["Sender"]
+	return (*response).Headers[HeaderSender]
 }
 
 // Value header
 func (response *Response) Value(i int) string {
-	return (*response).Headers["Value"]
+	return (*response).Headers[HeaderValue]
 }
 
 // Reference gets Reference* header
 func (response *Response) Reference(i int) string {
-	return (*response).Headers["Reference"+strconv.Itoa(i)]
+	return (*response).Headers[HeaderReferencePrefix+strconv.Itoa(i)]
+}
+
+// ReferenceOK gets Reference* header and whether it exists (distinguishes absent from empty)
+func (response *Response) ReferenceOK(i int) (string, bool) {
+	value, ok := (*response).Headers[HeaderReferencePrefix+strconv.Itoa(i)]
+	return value, ok
+}
+
+// References gets all Reference* headers by index; absent indexes are absent from the map
+func (response *Response) References() map[int]string {
+	return Headers((*response).Headers).References()
 }
 
 func (response Response) String() string {
-	return fmt.Sprintf("%s/%s %d %s\r\n%s\r\n", response.Protocol, response.Version, response.Code, response.Message(), response.Headers)
+	var builder strings.Builder
+	protocol := response.Protocol.String()
+	code := strconv.Itoa(response.Code)
+	message := response.Message()
+	builder.Grow(len(protocol) + 1 + len(response.Version) + 1 + len(code) + 1 + len(message) + 2 + Headers(response.Headers).size() + 2)
+	builder.WriteString(protocol)
+	builder.WriteByte('/')
+	builder.WriteString(response.Version)
+	builder.WriteByte(' ')
+	builder.WriteString(code)
+	builder.WriteByte(' ')
+	builder.WriteString(message)
+	builder.WriteString("\r\n")
+	Headers(response.Headers).writeTo(&builder)
+	builder.WriteString("\r\n")
+	return builder.String()
 }
 
 // Headers is SHIORI Message Headers
@@ -154,12 +275,41 @@ type RequestHeaders Headers
 type ResponseHeaders Headers
 
 func (headers Headers) String() string {
-	headersStr := ""
+	var builder strings.Builder
+	builder.Grow(headers.size())
+	headers.writeTo(&builder)
+	return builder.String()
+}
+
+// size returns serialized byte length of headers
+func (headers Headers) size() int {
+	size := 0
 	for key, value := range headers {
-		headersStr += key + ": " + value + "\r\n"
+		size += len(key) + 2 + len(value) + 2
 	}
-	return headersStr
+	return size
 }
+
+func (headers Headers) writeTo(builder io.StringWriter) {
+	for key, value := range headers {
+		builder.WriteString(key)
+		builder.WriteString(": ")
+		builder.WriteString(value)
+		builder.WriteString("\r\n")
+	}
+}
+
+// References gets all Reference* headers by index; absent indexes are absent from the map
+func (headers Headers) References() map[int]string {
+	references := map[int]string{}
+	for key, value := range headers {
+		if index, ok := referenceIndex(key); ok {
+			references[index] = value
+		}
+	}
+	return references
+}
+
 func (headers RequestHeaders) String() string {
 	return Headers(headers).String()
 }
@@ -167,6 +317,41 @@ func (headers ResponseHeaders) String() string {
 	return Headers(headers).String()
 }
 
+// Parser parses SHIORI messages with options.
+// The zero value is the strict parser used by ParseRequest and ParseResponse.
+type Parser struct {
+	// Lenient tolerates a leading UTF-8 BOM and trailing spaces or tabs before each CRLF.
+	// (A missing final blank line is always tolerated.)
+	// Note that trailing whitespace of header values is dropped too.
+	Lenient bool
+	// Folded accepts header values broken by legacy SHIORIs: bare LF inside a value is kept,
+	// and a line which is not a header is re-joined (with CRLF) to the value of the previous header.
+	Folded bool
+	// Hook is called after every parse by this parser (in addition to the hook set by SetParseHook)
+	Hook ParseHook
+	// HeaderCount is the expected number of headers to size the header map up front (the line count when 0).
+	// A connection which always sends requests of one shape can set it from the previous request.
+	HeaderCount int
+}
+
+// splitLines splits message into lines applying options
+func (parser Parser) splitLines(message string) []string {
+	return parser.normalizeLines(strings.Split(message, "\r\n"))
+}
+
+// normalizeLines applies options to lines (modifying it)
+func (parser Parser) normalizeLines(lines []string) []string {
+	if parser.Lenient {
+		if len(lines) != 0 {
+			lines[0] = strings.TrimPrefix(lines[0], "\uFEFF")
+		}
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+	}
+	return lines
+}
+
 var requestLineRe = regexp.MustCompile(`^(.+) SHIORI/(\d+\.\d+)$`)
 
 // ParseRequestError is Request parsing error
@@ -178,10 +363,40 @@ func (err ParseRequestError) Error() string {
 
 // ParseRequest converts SHIORI/x.x Request Message into Request type
 func ParseRequest(requestStr string) (Request, error) {
+	return Parser{}.ParseRequest(requestStr)
+}
+
+// ParseRequest converts SHIORI/x.x Request Message into Request type
+func (parser Parser) ParseRequest(requestStr string) (Request, error) {
+	if !parser.hooked() {
+		return parser.parseRequest(requestStr)
+	}
+	start := time.Now()
+	request, err := parser.parseRequest(requestStr)
+	parser.notify(ParseEvent{Raw: requestStr, Request: &request, Err: err, Duration: time.Since(start)})
+	return request, err
+}
+
+func (parser Parser) parseRequest(requestStr string) (Request, error) {
+	return parser.parseRequestLines(parser.splitLines(requestStr))
+}
+
+func (parser Parser) parseRequestLines(lines []string) (Request, error) {
+	request, err := parseRequestLine(lines[0])
+	if err != nil {
+		return request, err
+	}
+	headers, err := parser.parseHeaderLines(lines[1:])
+	request.Headers = RequestHeaders(headers)
+	if err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// parseRequestLine makes Request without headers from the request line
+func parseRequestLine(requestLine string) (Request, error) {
 	request := Request{Protocol: SHIORI}
-	lines := strings.Split(requestStr, "\r\n")
-	requestLine := lines[0]
-	headerLines := lines[1:]
 	requestLineResult := requestLineRe.FindStringSubmatch(requestLine)
 	if requestLineResult == nil {
 		return request, ParseRequestError("request line parse failed: " + requestLine)
@@ -192,14 +407,21 @@ func ParseRequest(requestStr string) (Request, error) {
 		return request, err
 	}
 	request.Version = requestLineResult[2]
-	headers, err := ParseHeaderLines(headerLines)
-	request.Headers = RequestHeaders(headers)
-	if err != nil {
-		return request, err
+	if request.Method.IsV2() && !strings.HasPrefix(request.Version, "2.") {
+		return request, ParseRequestError("SHIORI/2.x method with version " + request.Version + ": " + requestLine)
 	}
 	return request, nil
 }
 
+// MustParseRequest is like ParseRequest but panics if the message cannot be parsed
+func MustParseRequest(requestStr string) Request {
+	request, err := ParseRequest(requestStr)
+	if err != nil {
+		panic(err)
+	}
+	return request
+}
+
 // ParseResponseError is Response parsing error
 type ParseResponseError string
 
@@ -207,12 +429,31 @@ func (err ParseResponseError) Error() string {
 	return "ParseResponseError: " + string(err)
 }
 
-var statusLineRe = regexp.MustCompile(`^SHIORI/(\d+\.\d+) (\d+) (.+)$`)
+// the reason phrase may be empty or absent, as String writes no phrase for unknown codes
+var statusLineRe = regexp.MustCompile(`^SHIORI/(\d+\.\d+) (\d+)(?: (.*))?$`)
 
 // ParseResponse converts SHIORI/x.x Response Message into Response type
 func ParseResponse(responseStr string) (Response, error) {
+	return Parser{}.ParseResponse(responseStr)
+}
+
+// ParseResponse converts SHIORI/x.x Response Message into Response type
+func (parser Parser) ParseResponse(responseStr string) (Response, error) {
+	if !parser.hooked() {
+		return parser.parseResponse(responseStr)
+	}
+	start := time.Now()
+	response, err := parser.parseResponse(responseStr)
+	parser.notify(ParseEvent{Raw: responseStr, Response: &response, Err: err, Duration: time.Since(start)})
+	return response, err
+}
+
+func (parser Parser) parseResponse(responseStr string) (Response, error) {
+	return parser.parseResponseLines(parser.splitLines(responseStr))
+}
+
+func (parser Parser) parseResponseLines(lines []string) (Response, error) {
 	response := Response{Protocol: SHIORI}
-	lines := strings.Split(responseStr, "\r\n")
 	statusLine := lines[0]
 	headerLines := lines[1:]
 	statusLineResult := statusLineRe.FindStringSubmatch(statusLine)
@@ -223,9 +464,9 @@ func ParseResponse(responseStr string) (Response, error) {
 	var err error
 	response.Code, err = strconv.Atoi(statusLineResult[2])
 	if err != nil {
-		return response, err
+		return response, ParseResponseError("status code parse failed: " + statusLine)
 	}
-	headers, err := ParseHeaderLines(headerLines)
+	headers, err := parser.parseHeaderLines(headerLines)
 	response.Headers = ResponseHeaders(headers)
 	if err != nil {
 		return response, err
@@ -233,6 +474,15 @@ func ParseResponse(responseStr string) (Response, error) {
 	return response, nil
 }
 
+// MustParseResponse is like ParseResponse but panics if the message cannot be parsed
+func MustParseResponse(responseStr string) Response {
+	response, err := ParseResponse(responseStr)
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
 // ParseHeaderError is Header parsing error
 type ParseHeaderError string
 
@@ -244,7 +494,13 @@ var headerRe = regexp.MustCompile(`^([^:]+): (.*)$`)
 
 // ParseHeaderLines converts header lines into Headers type
 func ParseHeaderLines(headerLines []string) (Headers, error) {
-	headers := Headers{}
+	// every line holds at most one header, so the line count is an upper bound
+	return parseHeaderLines(headerLines, len(headerLines))
+}
+
+// parseHeaderLines is ParseHeaderLines with the header map sized for the count
+func parseHeaderLines(headerLines []string, count int) (Headers, error) {
+	headers := make(Headers, count)
 	for _, line := range headerLines {
 		if line == "" {
 			break
@@ -257,3 +513,34 @@ func ParseHeaderLines(headerLines []string) (Headers, error) {
 	}
 	return headers, nil
 }
+
+var foldedHeaderRe = regexp.MustCompile(`(?s)^([^:\n]+): (.*)$`)
+
+// parseHeaderLines converts header lines into Headers type applying options
+func (parser Parser) parseHeaderLines(headerLines []string) (Headers, error) {
+	count := parser.HeaderCount
+	if count <= 0 {
+		count = len(headerLines)
+	}
+	if !parser.Folded {
+		return parseHeaderLines(headerLines, count)
+	}
+	headers := make(Headers, count)
+	previousKey := ""
+	for _, line := range headerLines {
+		if line == "" {
+			break
+		}
+		headerResult := foldedHeaderRe.FindStringSubmatch(line)
+		if headerResult == nil {
+			if previousKey == "" {
+				return headers, ParseHeaderError("header line parse failed: " + line)
+			}
+			headers[previousKey] += "\r\n" + line
+			continue
+		}
+		headers[headerResult[1]] = headerResult[2]
+		previousKey = headerResult[1]
+	}
+	return headers, nil
+}
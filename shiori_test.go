@@ -0,0 +1,126 @@
+package shiori
+
+import (
+	"reflect"
+	"testing"
+)
+
+const benchRequestMessage = "GET SHIORI/3.0\r\n" +
+	"Charset: UTF-8\r\n" +
+	"Sender: SSP\r\n" +
+	"SecurityLevel: local\r\n" +
+	"ID: OnMouseDoubleClick\r\n" +
+	"Reference0: 0\r\n" +
+	"Reference1: 120\r\n" +
+	"Reference2: 0\r\n" +
+	"Reference3: 0\r\n" +
+	"Reference4: Head\r\n" +
+	"Reference5: 0\r\n" +
+	"\r\n"
+
+const benchResponseMessage = "SHIORI/3.0 200 OK\r\n" +
+	"Charset: UTF-8\r\n" +
+	"Sender: shiorigo\r\n" +
+	"Value: \\h\\s[0]Hello.\\w9\\u\\s[10]Hi.\\e\r\n" +
+	"\r\n"
+
+func BenchmarkRequestString(b *testing.B) {
+	request := MustParseRequest(benchRequestMessage)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = request.String()
+	}
+}
+
+func BenchmarkResponseString(b *testing.B) {
+	response := MustParseResponse(benchResponseMessage)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = response.String()
+	}
+}
+
+func BenchmarkHeadersString(b *testing.B) {
+	headers := Headers(MustParseRequest(benchRequestMessage).Headers)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = headers.String()
+	}
+}
+
+func BenchmarkParseRequest(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseRequest(benchRequestMessage); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseResponse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseResponse(benchResponseMessage); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundTrip(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		request, err := ParseRequest(benchRequestMessage)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = OK("\\h\\s[0]Hello.\\e", WithDefaultsFrom(request)).String()
+	}
+}
+
+// allocation budgets of the hot path; raise them only with a reason
+var allocBudgets = []struct {
+	name   string
+	budget float64
+	f      func()
+}{
+	{"ParseRequest", 27, func() { ParseRequest(benchRequestMessage) }},
+	{"ParseResponse", 11, func() { ParseResponse(benchResponseMessage) }},
+	{"Request.String", 2, func() { _ = benchRequest.String() }},
+	{"Response.String", 3, func() { _ = benchResponse.String() }},
+	{"round trip", 33, func() {
+		request, _ := ParseRequest(benchRequestMessage)
+		_ = OK("\\h\\s[0]Hello.\\e", WithDefaultsFrom(request)).String()
+	}},
+}
+
+var (
+	benchRequest  = MustParseRequest(benchRequestMessage)
+	benchResponse = MustParseResponse(benchResponseMessage)
+)
+
+func TestAllocBudgets(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector adds allocations")
+	}
+	for _, test := range allocBudgets {
+		t.Run(test.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, test.f); allocs > test.budget {
+				t.Errorf("%s allocates %v times, budget %v", test.name, allocs, test.budget)
+			}
+		})
+	}
+}
+
+func TestParserHeaderCount(t *testing.T) {
+	for _, count := range []int{0, 1, 11, 64} {
+		for _, folded := range []bool{false, true} {
+			request, err := Parser{HeaderCount: count, Folded: folded}.ParseRequest(benchRequestMessage)
+			if err != nil {
+				t.Fatalf("ParseRequest() with HeaderCount %d error = %v", count, err)
+			}
+			if !reflect.DeepEqual(request, benchRequest) {
+				t.Errorf("ParseRequest() with HeaderCount %d, Folded %v = %q, want %q", count, folded, request.String(), benchRequest.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package shioritest
+
+import (
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// unknownEventID is an event no SHIORI handles
+const unknownEventID = "OnShioritestUnknownEvent"
+
+// conformanceChecks are the behaviors RunConformance certifies
+var conformanceChecks = []struct {
+	name    string
+	request string
+	check   func(t *testing.T, request shiori.Request, response shiori.Response)
+}{
+	{
+		"unknown event is answered with 204",
+		"GET SHIORI/3.0\r\nCharset: UTF-8\r\nSender: SSP\r\nSecurityLevel: local\r\nID: " + unknownEventID + "\r\n\r\n",
+		func(t *testing.T, request shiori.Request, response shiori.Response) {
+			if response.Code != 204 {
+				t.Errorf("got %d %s, want 204 No Content", response.Code, response.Message())
+			}
+		},
+	},
+	{
+		"unknown NOTIFY is answered with 204",
+		"NOTIFY SHIORI/3.0\r\nCharset: UTF-8\r\nSender: SSP\r\nSecurityLevel: local\r\nID: " + unknownEventID + "\r\n\r\n",
+		func(t *testing.T, request shiori.Request, response shiori.Response) {
+			if response.Code != 204 {
+				t.Errorf("got %d %s, want 204 No Content", response.Code, response.Message())
+			}
+		},
+	},
+	{
+		"UTF-8 charset is echoed",
+		"GET SHIORI/3.0\r\nCharset: UTF-8\r\nSender: SSP\r\nSecurityLevel: local\r\nID: version\r\n\r\n",
+		checkCharset,
+	},
+	{
+		"Shift_JIS charset is echoed",
+		"GET SHIORI/3.0\r\nCharset: Shift_JIS\r\nSender: SSP\r\nSecurityLevel: local\r\nID: version\r\n\r\n",
+		checkCharset,
+	},
+	{
+		"GET version is answered with Value and Sender",
+		"GET SHIORI/3.0\r\nCharset: UTF-8\r\nSender: SSP\r\nSecurityLevel: local\r\nID: version\r\n\r\n",
+		func(t *testing.T, request shiori.Request, response shiori.Response) {
+			if response.Code != 200 || response.Value(0) == "" {
+				t.Errorf("got %d %s with Value %q, want 200 OK with the version", response.Code, response.Message(), response.Value(0))
+			}
+			if response.Version != "3.0" {
+				t.Errorf("got SHIORI/%s, want SHIORI/3.0", response.Version)
+			}
+			if response.Sender() == "" {
+				t.Error("Sender header naming the SHIORI is missing")
+			}
+		},
+	},
+	{
+		"SHIORI/2.x GET Version is answered in SHIORI/3.0",
+		"GET Version SHIORI/2.6\r\nCharset: UTF-8\r\nSender: SSP\r\n\r\n",
+		func(t *testing.T, request shiori.Request, response shiori.Response) {
+			if response.Code != 200 || response.Version != "3.0" {
+				t.Errorf("got SHIORI/%s %d %s, want SHIORI/3.0 200 OK", response.Version, response.Code, response.Message())
+			}
+		},
+	},
+}
+
+func checkCharset(t *testing.T, request shiori.Request, response shiori.Response) {
+	if response.Charset() != request.Charset() {
+		t.Errorf("got Charset %q, want %q", response.Charset(), request.Charset())
+	}
+}
+
+// RunConformance certifies the handler against behaviors SHIORI/3.0 requires, each as a subtest:
+// 204 No Content for unknown events, Charset of the request echoed, Sender header present,
+// GET version answered, and SHIORI/2.x GET Version answered in SHIORI/3.0.
+// Every response must also survive serialization (no CR or LF in headers).
+func RunConformance(t *testing.T, handler shiori.Handler) {
+	t.Helper()
+	for _, conformanceCheck := range conformanceChecks {
+		check := conformanceCheck.check
+		request := shiori.MustParseRequest(conformanceCheck.request)
+		t.Run(conformanceCheck.name, func(t *testing.T) {
+			response := handler.Serve(request.Clone())
+			if reparsed, err := shiori.ParseResponse(response.String()); err != nil || !SameResponse(reparsed, response) {
+				t.Fatalf("response cannot be serialized: %q", response.String())
+			}
+			check(t, request, response)
+		})
+	}
+}
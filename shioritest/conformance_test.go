@@ -0,0 +1,21 @@
+package shioritest
+
+import (
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+func TestRunConformance(t *testing.T) {
+	info := shiori.Info{Name: "test", Version: "1.0.0", Craftman: "test"}
+	mux := shiori.NewMux()
+	mux.Fallback = shiori.HandlerFunc(func(request shiori.Request) shiori.Response {
+		response, ok := info.Respond(request)
+		if !ok {
+			return shiori.NoContent(shiori.WithDefaultsFrom(request))
+		}
+		response.Headers[shiori.HeaderSender] = info.Name
+		return response
+	})
+	RunConformance(t, mux)
+}
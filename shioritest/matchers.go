@@ -0,0 +1,150 @@
+// Package shioritest provides assertion matchers for shiori.Response, golden transcript tests, a traffic replayer, a SHIORI/3.0 conformance suite, round-trip properties and fuzz corpus seeds.
+//
+// Matchers implement the gomega GomegaMatcher method set, so they can be passed to
+// Expect(response).To(...) directly, and Assert reports them through any
+// testing.TB-like value for use with plain tests or testify-style helpers.
+package shioritest
+
+import (
+	"fmt"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Matcher matches shiori.Response (or *shiori.Response)
+type Matcher interface {
+	Match(actual interface{}) (success bool, err error)
+	FailureMessage(actual interface{}) (message string)
+	NegatedFailureMessage(actual interface{}) (message string)
+}
+
+// TestingT is the subset of testing.TB used by Assert
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Assert reports every matcher that does not match the response and returns whether all matched
+func Assert(t TestingT, response shiori.Response, matchers ...Matcher) bool {
+	t.Helper()
+	ok := true
+	for _, matcher := range matchers {
+		success, err := matcher.Match(response)
+		if err != nil {
+			t.Errorf("%s", err)
+			ok = false
+		} else if !success {
+			t.Errorf("%s", matcher.FailureMessage(response))
+			ok = false
+		}
+	}
+	return ok
+}
+
+type responseMatcher struct {
+	description string
+	match       func(response shiori.Response) bool
+}
+
+func toResponse(actual interface{}) (shiori.Response, error) {
+	switch response := actual.(type) {
+	case shiori.Response:
+		return response, nil
+	case *shiori.Response:
+		if response == nil {
+			return shiori.Response{}, fmt.Errorf("shioritest: expected shiori.Response, got nil *shiori.Response")
+		}
+		return *response, nil
+	default:
+		return shiori.Response{}, fmt.Errorf("shioritest: expected shiori.Response, got %T", actual)
+	}
+}
+
+func (matcher responseMatcher) Match(actual interface{}) (bool, error) {
+	response, err := toResponse(actual)
+	if err != nil {
+		return false, err
+	}
+	return matcher.match(response), nil
+}
+
+func (matcher responseMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected response\n%s\nto %s", formatActual(actual), matcher.description)
+}
+
+func (matcher responseMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected response\n%s\nnot to %s", formatActual(actual), matcher.description)
+}
+
+func formatActual(actual interface{}) string {
+	response, err := toResponse(actual)
+	if err != nil {
+		return fmt.Sprintf("%#v", actual)
+	}
+	return "\t" + strings.ReplaceAll(strings.TrimRight(response.String(), "\r\n"), "\r\n", "\n\t")
+}
+
+// HaveCode matches response status code
+func HaveCode(code int) Matcher {
+	return responseMatcher{
+		description: fmt.Sprintf("have code %d", code),
+		match: func(response shiori.Response) bool {
+			return response.Code == code
+		},
+	}
+}
+
+// HaveHeader matches header value
+func HaveHeader(name string, value string) Matcher {
+	return responseMatcher{
+		description: fmt.Sprintf("have header %s: %s", name, value),
+		match: func(response shiori.Response) bool {
+			actual, ok := response.Headers[name]
+			return ok && actual == value
+		},
+	}
+}
+
+// ContainScript matches Value header containing the script fragment
+func ContainScript(fragment string) Matcher {
+	return responseMatcher{
+		description: fmt.Sprintf("contain script %q", fragment),
+		match: func(response shiori.Response) bool {
+			return strings.Contains(response.Headers[shiori.HeaderValue], fragment)
+		},
+	}
+}
+
+// RaiseEvent matches Value header containing \![raise,event...]
+func RaiseEvent(event string) Matcher {
+	return responseMatcher{
+		description: fmt.Sprintf("raise event %s", event),
+		match: func(response shiori.Response) bool {
+			value := response.Headers[shiori.HeaderValue]
+			tag := `\![raise,` + event
+			for {
+				index := strings.Index(value, tag)
+				if index < 0 {
+					return false
+				}
+				value = value[index+len(tag):]
+				if strings.HasPrefix(value, ",") || strings.HasPrefix(value, "]") {
+					return true
+				}
+			}
+		},
+	}
+}
+
+// HavePassThru matches X-SSTP-PassThru-* header value
+func HavePassThru(key string, value string) Matcher {
+	name := shiori.HeaderSSTPPassThruPrefix + key
+	return responseMatcher{
+		description: fmt.Sprintf("have header %s: %s", name, value),
+		match: func(response shiori.Response) bool {
+			actual, ok := response.Headers[name]
+			return ok && actual == value
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package shioritest
+
+import (
+	"reflect"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// RequestRoundTrip reports whether the request parses back to itself from its serialization,
+// a property for testing/quick with the generator of shiori.Request, e.g.
+//
+//	if err := quick.Check(shioritest.RequestRoundTrip, nil); err != nil {
+//		t.Error(err)
+//	}
+func RequestRoundTrip(request shiori.Request) bool {
+	parsed, err := shiori.ParseRequest(request.String())
+	return err == nil && reflect.DeepEqual(parsed, request)
+}
+
+// ResponseRoundTrip reports whether the response parses back to itself from its serialization (see RequestRoundTrip)
+func ResponseRoundTrip(response shiori.Response) bool {
+	parsed, err := shiori.ParseResponse(response.String())
+	return err == nil && reflect.DeepEqual(parsed, response)
+}
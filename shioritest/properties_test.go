@@ -0,0 +1,15 @@
+package shioritest
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestRoundTripProperties(t *testing.T) {
+	if err := quick.Check(RequestRoundTrip, nil); err != nil {
+		t.Errorf("RequestRoundTrip: %v", err)
+	}
+	if err := quick.Check(ResponseRoundTrip, nil); err != nil {
+		t.Errorf("ResponseRoundTrip: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package shioritest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Record is one line of a JSONL log of recorded traffic
+type Record struct {
+	// Time is when the request was received
+	Time time.Time `json:"time"`
+	// Request is the request message
+	Request string `json:"request"`
+	// Response is the response message
+	Response string `json:"response"`
+}
+
+// ReadLog reads a JSONL log of Records (blank lines are skipped)
+func ReadLog(r io.Reader) ([]Exchange, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	var exchanges []Exchange
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return exchanges, fmt.Errorf("shioritest: log line %d: %w", line, err)
+		}
+		request, err := shiori.ParseRequest(record.Request)
+		if err != nil {
+			return exchanges, fmt.Errorf("shioritest: log line %d request: %w", line, err)
+		}
+		response, err := shiori.ParseResponse(record.Response)
+		if err != nil {
+			return exchanges, fmt.Errorf("shioritest: log line %d response: %w", line, err)
+		}
+		exchanges = append(exchanges, Exchange{Time: record.Time, Request: request, Response: response})
+	}
+	return exchanges, scanner.Err()
+}
+
+// Replayer replays recorded exchanges against a handler
+type Replayer struct {
+	// Speed multiplies the recorded pace, e.g. 2 replays twice as fast; 0 replays as fast as possible.
+	// Exchanges without Time (such as those of transcripts) are never waited for.
+	Speed float64
+	// Sleep waits between requests (time.Sleep when nil)
+	Sleep func(duration time.Duration)
+}
+
+// Divergence is a replayed response which differs from the recorded one
+type Divergence struct {
+	// Index is 0-based index of the exchange
+	Index    int
+	Exchange Exchange
+	Actual   shiori.Response
+}
+
+func (divergence Divergence) String() string {
+	request := divergence.Exchange.Request
+	return fmt.Sprintf("exchange %d (%s %s):\n%s", divergence.Index+1, request.Method, request.EventID(), DiffResponse(divergence.Exchange.Response, divergence.Actual))
+}
+
+// ReplayReport is the result of Replay
+type ReplayReport struct {
+	// Count is number of replayed exchanges
+	Count int
+	// Elapsed is time spent replaying, including waits
+	Elapsed time.Duration
+	// Divergences are the exchanges whose responses differed
+	Divergences []Divergence
+}
+
+// Replay feeds the requests to the handler in order and compares the responses with the recorded ones
+func (replayer Replayer) Replay(handler shiori.Handler, exchanges []Exchange) ReplayReport {
+	sleep := replayer.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	report := ReplayReport{}
+	start := time.Now()
+	for i, exchange := range exchanges {
+		if i > 0 && replayer.Speed > 0 && !exchange.Time.IsZero() && !exchanges[i-1].Time.IsZero() {
+			if gap := exchange.Time.Sub(exchanges[i-1].Time); gap > 0 {
+				sleep(time.Duration(float64(gap) / replayer.Speed))
+			}
+		}
+		actual := handler.Serve(exchange.Request.Clone())
+		if !SameResponse(actual, exchange.Response) {
+			report.Divergences = append(report.Divergences, Divergence{Index: i, Exchange: exchange, Actual: actual})
+		}
+		report.Count++
+	}
+	report.Elapsed = time.Since(start)
+	return report
+}
@@ -0,0 +1,41 @@
+package shioritest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const replayLog = `{"time":"2026-10-14T10:00:00Z","request":"GET SHIORI/3.0\r\nCharset: UTF-8\r\nID: OnBoot\r\nReference0: master\r\n\r\n","response":"SHIORI/3.0 200 OK\r\nCharset: UTF-8\r\nSender: test\r\nValue: \\h\\s[0]Hello, master.\\e\r\n\r\n"}
+
+{"time":"2026-10-14T10:00:04Z","request":"GET SHIORI/3.0\r\nCharset: UTF-8\r\nID: OnClose\r\n\r\n","response":"SHIORI/3.0 200 OK\r\nCharset: UTF-8\r\nValue: \\h\\s[0]Bye.\\e\\-\r\n\r\n"}
+`
+
+func TestReplay(t *testing.T) {
+	exchanges, err := ReadLog(strings.NewReader(replayLog))
+	if err != nil {
+		t.Fatalf("ReadLog() error = %v", err)
+	}
+	tests := []struct {
+		name      string
+		speed     float64
+		wantSleep []time.Duration
+	}{
+		{"as fast as possible", 0, nil},
+		{"recorded pace", 1, []time.Duration{4 * time.Second}},
+		{"twice as fast", 2, []time.Duration{2 * time.Second}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var slept []time.Duration
+			replayer := Replayer{Speed: test.speed, Sleep: func(duration time.Duration) { slept = append(slept, duration) }}
+			report := replayer.Replay(bootHandler("Hello"), exchanges)
+			if report.Count != 2 || len(report.Divergences) != 1 || report.Divergences[0].Index != 1 {
+				t.Errorf("report = %+v, want OnClose diverged", report)
+			}
+			if len(slept) != len(test.wantSleep) || (len(slept) != 0 && slept[0] != test.wantSleep[0]) {
+				t.Errorf("slept %v, want %v", slept, test.wantSleep)
+			}
+		})
+	}
+}
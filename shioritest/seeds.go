@@ -0,0 +1,100 @@
+package shioritest
+
+import (
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Seeder is the subset of testing.F used by AddSeeds
+type Seeder interface {
+	Add(args ...interface{})
+}
+
+// AddSeeds adds each seed as a fuzz corpus entry, e.g. AddSeeds(f, RequestSeeds()...)
+func AddSeeds(f Seeder, seeds ...string) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+}
+
+// RequestSeeds returns SHIORI request messages for fuzz corpora:
+// one request per event in the catalog, SHIORI/2.x forms and known malformed traffic
+func RequestSeeds() []string {
+	var seeds []string
+	for _, event := range shiori.Events() {
+		method, err := shiori.ToMethod(event.Method)
+		if err != nil {
+			method = shiori.GET
+		}
+		references := make([]string, len(event.References))
+		for i, reference := range event.References {
+			switch reference.Type {
+			case "int":
+				references[i] = "10"
+			case "bool":
+				references[i] = "1"
+			case "list":
+				references[i] = "a\x01b"
+			default:
+				references[i] = reference.Name
+			}
+		}
+		request := shiori.NewRequest(method, event.ID, references...)
+		request.Headers[shiori.HeaderCharset] = "UTF-8"
+		request.Headers[shiori.HeaderSender] = "SSP"
+		seeds = append(seeds, request.String())
+	}
+	return append(seeds,
+		"GET Version SHIORI/2.6\r\nCharset: Shift_JIS\r\nSender: SSP\r\n\r\n",
+		"GET Sentence SHIORI/2.6\r\nEvent: OnBoot\r\nReference0: master\r\n\r\n",
+		"NOTIFY OwnerGhostName SHIORI/2.0\r\nGhost: sakura\r\n\r\n",
+		"\uFEFFGET SHIORI/3.0 \r\nID: OnBoot\t\r\n",
+		"GET SHIORI/3.0\r\nID: OnBoot\r\nReference0: a\r\nbroken\r\n\r\n",
+		"GET SHIORI/3.0\r\nID: OnBoot\nReference0: bare LF\r\n\r\n",
+		"GET SHIORI/3.0",
+		"",
+	)
+}
+
+// ResponseSeeds returns SHIORI response messages for fuzz corpora
+func ResponseSeeds() []string {
+	request := shiori.NewOnBootRequest("master")
+	request.Headers[shiori.HeaderCharset] = "UTF-8"
+	return []string{
+		shiori.OK("\\h\\s[0]Hello.\\e", shiori.WithDefaultsFrom(request), shiori.WithSender("shiorigo")).String(),
+		shiori.NoContent(shiori.WithDefaultsFrom(request)).String(),
+		shiori.BadRequest("bad").String(),
+		shiori.InternalError(nil).String(),
+		"SHIORI/2.6 200 OK\r\nSentence: \\h\\e\r\n\r\n",
+		"SHIORI/3.0 999 \r\n\r\n",
+		"SHIORI/3.0 200 OK\r\nValue: no blank line",
+		"SHIORI/3.0 OK\r\n\r\n",
+		"",
+	}
+}
+
+// SSTPSeeds returns SSTP/1.x request and response messages for fuzz corpora
+func SSTPSeeds() []string {
+	return []string{
+		"SEND SSTP/1.4\r\nSender: test\r\nIfGhost: sakura,kero\r\nScript: \\h\\s0sakura\\e\r\nScript: \\h\\s0any\\e\r\nOption: nodescript,notranslate\r\nCharset: UTF-8\r\n\r\n",
+		"NOTIFY SSTP/1.1\r\nSender: test\r\nEvent: OnTest\r\nReference0: a\r\nX-SSTP-PassThru-Foo: bar\r\nCharset: UTF-8\r\n\r\n",
+		"EXECUTE SSTP/1.3\r\nSender: test\r\nCommand: GetVersion\r\nCharset: UTF-8\r\n\r\n",
+		"COMMUNICATE SSTP/1.2\r\nSender: test\r\nSentence: hello\r\n\r\n",
+		"GIVE SSTP/1.1\r\nSender: test\r\nDocument: text\r\n\r\n",
+		"SSTP/1.4 200 OK\r\nCharset: UTF-8\r\nX-SSTP-PassThru-A: 1\r\n\r\n",
+		"SSTP/1.1 200 OK\r\nSSP\r\n\r\n",
+		"SSTP/1.0 404\r\n\r\n",
+	}
+}
+
+// SAORISeeds returns SAORI/1.0 request and response messages for fuzz corpora
+func SAORISeeds() []string {
+	return []string{
+		"GET Version SAORI/1.0\r\nCharset: UTF-8\r\nSender: SSP\r\n\r\n",
+		"EXECUTE SAORI/1.0\r\nCharset: Shift_JIS\r\nSender: SSP\r\nSecurityLevel: Local\r\nArgument0: a\r\nArgument1: b\r\n\r\n",
+		"SAORI/1.0 200 OK\r\nCharset: UTF-8\r\nResult: r\r\nValue0: v0\r\nValue10: v10\r\n\r\n",
+		"SAORI/1.0 404 \r\n\r\n",
+		"SAORI/1.0 400\r\n\r\n",
+		"EXECUTE SAORI/1.0\r\nArgument0: no blank line",
+		"",
+	}
+}
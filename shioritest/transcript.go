@@ -0,0 +1,127 @@
+package shioritest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Exchange is a request and the response expected for it
+type Exchange struct {
+	// Time is when the request was recorded (zero for transcripts)
+	Time     time.Time
+	Request  shiori.Request
+	Response shiori.Response
+}
+
+// ReadTranscript reads a transcript of alternating requests and responses, each ended by a blank line.
+// Transcripts written with bare LF line endings are accepted, and each message is decoded from the charset of its Charset header.
+func ReadTranscript(r io.Reader) ([]Exchange, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(data, []byte("\r\n")) {
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
+	scanner := shiori.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	var exchanges []Exchange
+	for scanner.Scan() {
+		request, err := shiori.DecodeRequest(scanner.Bytes())
+		if err != nil {
+			return exchanges, fmt.Errorf("shioritest: transcript request %d: %w", len(exchanges)+1, err)
+		}
+		if !scanner.Scan() {
+			return exchanges, fmt.Errorf("shioritest: transcript request %d has no response", len(exchanges)+1)
+		}
+		response, err := shiori.DecodeResponse(scanner.Bytes())
+		if err != nil {
+			return exchanges, fmt.Errorf("shioritest: transcript response %d: %w", len(exchanges)+1, err)
+		}
+		exchanges = append(exchanges, Exchange{Request: request, Response: response})
+	}
+	return exchanges, scanner.Err()
+}
+
+// LoadTranscript reads the transcript file (see ReadTranscript)
+func LoadTranscript(path string) ([]Exchange, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadTranscript(file)
+}
+
+// RunTranscript feeds each request of the transcript file to the handler
+// and reports every response which differs from the expected one, and returns whether all matched
+func RunTranscript(t TestingT, handler shiori.Handler, path string) bool {
+	t.Helper()
+	exchanges, err := LoadTranscript(path)
+	if err != nil {
+		t.Errorf("%s", err)
+		return false
+	}
+	report := Replayer{}.Replay(handler, exchanges)
+	for _, divergence := range report.Divergences {
+		t.Errorf("%s: %s", path, divergence)
+	}
+	return len(report.Divergences) == 0
+}
+
+// SameResponse reports whether the responses have the same status line and headers
+func SameResponse(a shiori.Response, b shiori.Response) bool {
+	return a.Code == b.Code && a.Version == b.Version && reflect.DeepEqual(shiori.Headers(a.Headers), shiori.Headers(b.Headers))
+}
+
+// DiffResponse describes differences of actual response from expected one line by line
+// ("-" for expected, "+" for actual and " " for equal lines)
+func DiffResponse(expected shiori.Response, actual shiori.Response) string {
+	var builder strings.Builder
+	diffLine(&builder, statusLine(expected), statusLine(actual))
+	expectedHeaders := shiori.Headers(expected.Headers)
+	actualHeaders := shiori.Headers(actual.Headers)
+	merged := expectedHeaders.Clone()
+	for key, value := range actualHeaders {
+		merged[key] = value
+	}
+	for _, key := range merged.SortedKeys() {
+		expectedLine := headerLine(expectedHeaders, key)
+		actualLine := headerLine(actualHeaders, key)
+		diffLine(&builder, expectedLine, actualLine)
+	}
+	return builder.String()
+}
+
+func statusLine(response shiori.Response) string {
+	return fmt.Sprintf("%s/%s %d %s", response.Protocol, response.Version, response.Code, response.Message())
+}
+
+// headerLine is "" for absent headers
+func headerLine(headers shiori.Headers, key string) string {
+	value, ok := headers[key]
+	if !ok {
+		return ""
+	}
+	return key + ": " + value
+}
+
+func diffLine(builder *strings.Builder, expected string, actual string) {
+	if expected == actual {
+		builder.WriteString("  " + expected + "\n")
+		return
+	}
+	if expected != "" {
+		builder.WriteString("- " + expected + "\n")
+	}
+	if actual != "" {
+		builder.WriteString("+ " + actual + "\n")
+	}
+}
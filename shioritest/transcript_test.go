@@ -0,0 +1,52 @@
+package shioritest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (recorder *recorder) Helper() {}
+
+func (recorder *recorder) Errorf(format string, args ...interface{}) {
+	recorder.errors = append(recorder.errors, fmt.Sprintf(format, args...))
+}
+
+func bootHandler(greeting string) shiori.Handler {
+	mux := shiori.NewMux()
+	mux.HandleFunc("OnBoot", func(request shiori.Request) shiori.Response {
+		return shiori.OK(`\h\s[0]`+greeting+", "+request.Reference(0)+`.\e`, shiori.WithDefaultsFrom(request), shiori.WithSender("test"))
+	})
+	return mux
+}
+
+func TestRunTranscript(t *testing.T) {
+	RunTranscript(t, bootHandler("Hello"), "testdata/boot.txt")
+}
+
+func TestRunTranscriptDiff(t *testing.T) {
+	recorder := &recorder{}
+	if RunTranscript(recorder, bootHandler("Hi"), "testdata/boot.txt") {
+		t.Fatal("RunTranscript() = true for a differing handler")
+	}
+	if len(recorder.errors) != 1 {
+		t.Fatalf("errors = %q, want 1 error", recorder.errors)
+	}
+	for _, want := range []string{"exchange 1 (GET OnBoot)", `- Value: \h\s[0]Hello, master.\e`, `+ Value: \h\s[0]Hi, master.\e`, "  Sender: test"} {
+		if !strings.Contains(recorder.errors[0], want) {
+			t.Errorf("error = %q, want it to contain %q", recorder.errors[0], want)
+		}
+	}
+}
+
+func TestReadTranscriptUnpaired(t *testing.T) {
+	if _, err := ReadTranscript(strings.NewReader("GET SHIORI/3.0\nID: OnBoot\n\n")); err == nil {
+		t.Error("ReadTranscript() accepted a request without response")
+	}
+}
@@ -0,0 +1,43 @@
+package sstp_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Narazaka/shiorigo/shioritest"
+	"github.com/Narazaka/shiorigo/sstp"
+)
+
+func FuzzParseSSTP(f *testing.F) {
+	shioritest.AddSeeds(f, shioritest.SSTPSeeds()...)
+	f.Fuzz(func(t *testing.T, message string) {
+		sstp.DecodeRequest([]byte(message))
+		sstp.DecodeResponse([]byte(message))
+		if strings.HasPrefix(message, "SSTP/") {
+			response, err := sstp.ParseResponse(message)
+			if err != nil {
+				return
+			}
+			reparsed, err := sstp.ParseResponse(response.String())
+			if err != nil {
+				t.Fatalf("ParseResponse(%q) of serialized response error = %v", response.String(), err)
+			}
+			if !reflect.DeepEqual(reparsed, response) {
+				t.Errorf("round trip = %q, want %q", reparsed.String(), response.String())
+			}
+			return
+		}
+		request, err := sstp.ParseRequest(message)
+		if err != nil {
+			return
+		}
+		reparsed, err := sstp.ParseRequest(request.String())
+		if err != nil {
+			t.Fatalf("ParseRequest(%q) of serialized request error = %v", request.String(), err)
+		}
+		if !reflect.DeepEqual(reparsed, request) {
+			t.Errorf("round trip = %q, want %q", reparsed.String(), request.String())
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package sstp
+
+import shiori "github.com/Narazaka/shiorigo"
+
+// Standard SSTP/1.x header names.
+const (
+	// HeaderCharset is Charset header
+	HeaderCharset = shiori.HeaderCharset
+	// HeaderSender is Sender header
+	HeaderSender = shiori.HeaderSender
+	// HeaderScript is Script header (SEND, NOTIFY, COMMUNICATE)
+	HeaderScript = "Script"
+	// HeaderEvent is Event header (NOTIFY)
+	HeaderEvent = shiori.HeaderEvent
+	// HeaderOption is Option header; comma separated options such as "nodescript,notranslate"
+	HeaderOption = "Option"
+	// HeaderIfGhost is IfGhost header which selects the following Script header by ghost names
+	HeaderIfGhost = "IfGhost"
+	// HeaderHWnd is HWnd header (window handle of the sender)
+	HeaderHWnd = "HWnd"
+	// HeaderEntry is Entry header (SEND/1.2)
+	HeaderEntry = "Entry"
+	// HeaderCommand is Command header (EXECUTE)
+	HeaderCommand = "Command"
+	// HeaderSentence is Sentence header (COMMUNICATE)
+	HeaderSentence = shiori.HeaderSentence
+	// HeaderReferencePrefix is prefix of Reference* headers
+	HeaderReferencePrefix = shiori.HeaderReferencePrefix
+	// HeaderPassThruPrefix is prefix of X-SSTP-PassThru-* headers
+	HeaderPassThruPrefix = shiori.HeaderSSTPPassThruPrefix
+)
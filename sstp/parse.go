@@ -0,0 +1,124 @@
+package sstp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+var (
+	requestLineRe = regexp.MustCompile(`^(\S+) SSTP/(\d+\.\d+)$`)
+	statusLineRe  = regexp.MustCompile(`^SSTP/(\d+\.\d+) (\d+) ?(.*)$`)
+	headerRe      = regexp.MustCompile(`^([^:]+): (.*)$`)
+)
+
+// ParseRequestError is Request parsing error
+type ParseRequestError string
+
+func (err ParseRequestError) Error() string {
+	return "ParseRequestError: " + string(err)
+}
+
+// Is reports ParseRequestError matches shiori.ErrParse
+func (err ParseRequestError) Is(target error) bool {
+	return target == shiori.ErrParse
+}
+
+// ParseResponseError is Response parsing error
+type ParseResponseError string
+
+func (err ParseResponseError) Error() string {
+	return "ParseResponseError: " + string(err)
+}
+
+// Is reports ParseResponseError matches shiori.ErrParse
+func (err ParseResponseError) Is(target error) bool {
+	return target == shiori.ErrParse
+}
+
+// ParseRequest converts SSTP/1.x Request Message into Request type.
+// Script headers following IfGhost headers are collected into IfGhost pairs.
+func ParseRequest(requestStr string) (Request, error) {
+	request := Request{Headers: shiori.Headers{}}
+	lines := strings.Split(requestStr, "\r\n")
+	requestLineResult := requestLineRe.FindStringSubmatch(lines[0])
+	if requestLineResult == nil {
+		return request, ParseRequestError("request line parse failed: " + lines[0])
+	}
+	var err error
+	request.Method, err = ToMethod(requestLineResult[1])
+	if err != nil {
+		return request, err
+	}
+	request.Version = requestLineResult[2]
+	pendingIfGhost := false
+	for _, line := range lines[1:] {
+		if line == "" {
+			break
+		}
+		headerResult := headerRe.FindStringSubmatch(line)
+		if headerResult == nil {
+			return request, ParseRequestError("header line parse failed: " + line)
+		}
+		key, value := headerResult[1], headerResult[2]
+		switch {
+		case key == HeaderIfGhost:
+			request.IfGhost = append(request.IfGhost, IfGhostScript{IfGhost: value})
+			pendingIfGhost = true
+		case key == HeaderScript && pendingIfGhost:
+			request.IfGhost[len(request.IfGhost)-1].Script = value
+			pendingIfGhost = false
+		default:
+			request.Headers[key] = value
+		}
+	}
+	return request, nil
+}
+
+// ParseResponse converts SSTP/1.x Response Message into Response type.
+// Lines which are not headers are collected into Additional.
+func ParseResponse(responseStr string) (Response, error) {
+	response := Response{Headers: shiori.Headers{}}
+	lines := strings.Split(responseStr, "\r\n")
+	statusLineResult := statusLineRe.FindStringSubmatch(lines[0])
+	if statusLineResult == nil {
+		return response, ParseResponseError("status line parse failed: " + lines[0])
+	}
+	response.Version = statusLineResult[1]
+	var err error
+	response.Code, err = strconv.Atoi(statusLineResult[2])
+	if err != nil {
+		return response, ParseResponseError("status code parse failed: " + lines[0])
+	}
+	for _, line := range lines[1:] {
+		if line == "" {
+			break
+		}
+		if headerResult := headerRe.FindStringSubmatch(line); headerResult != nil {
+			response.Headers[headerResult[1]] = headerResult[2]
+		} else {
+			response.Additional = append(response.Additional, line)
+		}
+	}
+	return response, nil
+}
+
+// DecodeRequest converts raw SSTP/1.x Request Message in the charset declared by its Charset header into Request type
+func DecodeRequest(data []byte) (Request, error) {
+	message, err := shiori.DecodeMessage(data)
+	if err != nil {
+		return Request{}, err
+	}
+	return ParseRequest(message)
+}
+
+// DecodeResponse converts raw SSTP/1.x Response Message in the charset declared by its Charset header into Response type
+func DecodeResponse(data []byte) (Response, error) {
+	message, err := shiori.DecodeMessage(data)
+	if err != nil {
+		return Response{}, err
+	}
+	return ParseResponse(message)
+}
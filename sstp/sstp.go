@@ -0,0 +1,303 @@
+// Package sstp implements SSTP/1.x messages (SEND, NOTIFY, COMMUNICATE, EXECUTE, GIVE).
+//
+// SSTP messages have the same shape as SHIORI messages, so shiori.NewScanner frames them on a stream
+// and shiori.Headers helpers work on their headers.
+package sstp
+
+import (
+	"strconv"
+	"strings"
+
+	shiori "github.com/Narazaka/shiorigo"
+)
+
+// Method is SSTP Request Method
+type Method int
+
+const (
+	// InvalidMethod is reserved value for error case
+	InvalidMethod Method = iota
+	// SEND is SEND SSTP/1.x
+	SEND
+	// NOTIFY is NOTIFY SSTP/1.x
+	NOTIFY
+	// COMMUNICATE is COMMUNICATE SSTP/1.x
+	COMMUNICATE
+	// EXECUTE is EXECUTE SSTP/1.x
+	EXECUTE
+	// GIVE is GIVE SSTP/1.x
+	GIVE
+)
+
+var methodNames = map[Method]string{
+	SEND:        "SEND",
+	NOTIFY:      "NOTIFY",
+	COMMUNICATE: "COMMUNICATE",
+	EXECUTE:     "EXECUTE",
+	GIVE:        "GIVE",
+}
+
+func (method Method) String() string {
+	return methodNames[method]
+}
+
+// DefaultVersions are the latest SSTP versions of each method, used by NewRequest
+var DefaultVersions = map[Method]string{
+	SEND:        "1.4",
+	NOTIFY:      "1.1",
+	COMMUNICATE: "1.2",
+	EXECUTE:     "1.3",
+	GIVE:        "1.1",
+}
+
+// DefaultVersion is SSTP version used by NewResponse
+const DefaultVersion = "1.4"
+
+// InvalidMethodError is invalid method error
+type InvalidMethodError string
+
+func (err InvalidMethodError) Error() string {
+	return "InvalidMethodError: " + string(err)
+}
+
+// Is reports InvalidMethodError matches shiori.ErrInvalidMethod and shiori.ErrParse
+func (err InvalidMethodError) Is(target error) bool {
+	return target == shiori.ErrInvalidMethod || target == shiori.ErrParse
+}
+
+// ToMethod converts method string into Method type
+func ToMethod(method string) (Method, error) {
+	for value, name := range methodNames {
+		if name == method {
+			return value, nil
+		}
+	}
+	return InvalidMethod, InvalidMethodError(method)
+}
+
+// IfGhostScript is a pair of IfGhost header and the Script header following it
+type IfGhostScript struct {
+	// IfGhost is comma separated ghost names (\0 name first)
+	IfGhost string
+	Script  string
+}
+
+// Request is SSTP/1.x Request Message
+type Request struct {
+	Method  Method
+	Version string
+	// Headers holds headers other than IfGhost and the Script headers paired with them
+	Headers shiori.Headers
+	// IfGhost holds IfGhost/Script header pairs in message order
+	IfGhost []IfGhostScript
+}
+
+// NewRequest makes Request of the method with DefaultVersions and the headers
+func NewRequest(method Method, headers shiori.Headers) Request {
+	if headers == nil {
+		headers = shiori.Headers{}
+	}
+	return Request{Method: method, Version: DefaultVersions[method], Headers: headers}
+}
+
+// Charset header
+func (request *Request) Charset() string {
+	return (*request).Headers[HeaderCharset]
+}
+
+// Sender header
+func (request *Request) Sender() string {
+	return (*request).Headers[HeaderSender]
+}
+
+// Script header which is not paired with IfGhost
+func (request *Request) Script() string {
+	return (*request).Headers[HeaderScript]
+}
+
+// ScriptFor gets the Script paired with IfGhost whose \0 name is the ghost, or Script header if none matches
+func (request *Request) ScriptFor(ghost string) string {
+	for _, pair := range (*request).IfGhost {
+		if strings.SplitN(pair.IfGhost, ",", 2)[0] == ghost {
+			return pair.Script
+		}
+	}
+	return (*request).Script()
+}
+
+// Event header
+func (request *Request) Event() string {
+	return (*request).Headers[HeaderEvent]
+}
+
+// Options gets Option header split by comma
+func (request *Request) Options() []string {
+	return splitOptions((*request).Headers[HeaderOption])
+}
+
+// HasOption reports whether Option header contains the option
+func (request *Request) HasOption(option string) bool {
+	for _, value := range (*request).Options() {
+		if value == option {
+			return true
+		}
+	}
+	return false
+}
+
+// Reference gets Reference* header
+func (request *Request) Reference(i int) string {
+	return (*request).Headers[HeaderReferencePrefix+strconv.Itoa(i)]
+}
+
+// PassThru gets X-SSTP-PassThru-* header
+func (request *Request) PassThru(name string) string {
+	return (*request).Headers[HeaderPassThruPrefix+name]
+}
+
+// PassThrus gets all X-SSTP-PassThru-* headers by name without the prefix
+func (request *Request) PassThrus() map[string]string {
+	return passThrus((*request).Headers)
+}
+
+func (request Request) String() string {
+	var builder strings.Builder
+	builder.WriteString(request.Method.String())
+	builder.WriteString(" SSTP/")
+	builder.WriteString(request.Version)
+	builder.WriteString("\r\n")
+	request.Headers.WriteSorted(&builder, HeaderScript)
+	for _, pair := range request.IfGhost {
+		writeHeader(&builder, HeaderIfGhost, pair.IfGhost)
+		writeHeader(&builder, HeaderScript, pair.Script)
+	}
+	if script, ok := request.Headers[HeaderScript]; ok {
+		writeHeader(&builder, HeaderScript, script)
+	}
+	builder.WriteString("\r\n")
+	return builder.String()
+}
+
+// Encode converts the request into SSTP/1.x Request Message in the charset declared by its Charset header (UTF-8 if none)
+func (request Request) Encode() ([]byte, error) {
+	return shiori.EncodeMessage(request.String(), request.Charset())
+}
+
+// Response is SSTP/1.x Response Message
+type Response struct {
+	Version string
+	Code    int
+	Headers shiori.Headers
+	// Additional holds lines which are not headers, such as result of EXECUTE
+	Additional []string
+}
+
+// NewResponse makes Response of the code with DefaultVersion
+func NewResponse(code int) Response {
+	return Response{Version: DefaultVersion, Code: code, Headers: shiori.Headers{}}
+}
+
+// Message gets the reason phrase of the status code
+func (response *Response) Message() string {
+	switch (*response).Code {
+	case 200:
+		return "OK"
+	case 204:
+		return "No Content"
+	case 210:
+		return "Break"
+	case 400:
+		return "Bad Request"
+	case 404:
+		return "Not Found"
+	case 408:
+		return "Request Timeout"
+	case 409:
+		return "Conflict"
+	case 420:
+		return "Refuse"
+	case 501:
+		return "Not Implemented"
+	case 503:
+		return "Service Unavailable"
+	case 510:
+		return "Not Local IP"
+	case 511:
+		return "In Black List"
+	case 512:
+		return "Invisible"
+	default:
+		return ""
+	}
+}
+
+// Charset header
+func (response *Response) Charset() string {
+	return (*response).Headers[HeaderCharset]
+}
+
+// Script header
+func (response *Response) Script() string {
+	return (*response).Headers[HeaderScript]
+}
+
+// PassThru gets X-SSTP-PassThru-* header
+func (response *Response) PassThru(name string) string {
+	return (*response).Headers[HeaderPassThruPrefix+name]
+}
+
+// PassThrus gets all X-SSTP-PassThru-* headers by name without the prefix
+func (response *Response) PassThrus() map[string]string {
+	return passThrus((*response).Headers)
+}
+
+func (response Response) String() string {
+	var builder strings.Builder
+	builder.WriteString("SSTP/")
+	builder.WriteString(response.Version)
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(response.Code))
+	builder.WriteByte(' ')
+	builder.WriteString(response.Message())
+	builder.WriteString("\r\n")
+	for _, line := range response.Additional {
+		builder.WriteString(line)
+		builder.WriteString("\r\n")
+	}
+	response.Headers.WriteSorted(&builder)
+	builder.WriteString("\r\n")
+	return builder.String()
+}
+
+// Encode converts the response into SSTP/1.x Response Message in the charset declared by its Charset header (UTF-8 if none)
+func (response Response) Encode() ([]byte, error) {
+	return shiori.EncodeMessage(response.String(), response.Charset())
+}
+
+func writeHeader(builder *strings.Builder, key string, value string) {
+	builder.WriteString(key)
+	builder.WriteString(": ")
+	builder.WriteString(value)
+	builder.WriteString("\r\n")
+}
+
+func splitOptions(option string) []string {
+	if option == "" {
+		return nil
+	}
+	options := strings.Split(option, ",")
+	for i, value := range options {
+		options[i] = strings.TrimSpace(value)
+	}
+	return options
+}
+
+func passThrus(headers shiori.Headers) map[string]string {
+	values := map[string]string{}
+	for key, value := range headers {
+		if strings.HasPrefix(key, HeaderPassThruPrefix) {
+			values[key[len(HeaderPassThruPrefix):]] = value
+		}
+	}
+	return values
+}
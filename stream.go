@@ -0,0 +1,139 @@
+package shiori
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readLines reads lines of one message (up to the blank line) from r.
+// Only CRLF ends a line, as in ParseRequest; io.EOF is returned only when nothing was read.
+// With Lenient a line of only spaces and tabs is blank too, as normalizeLines trims it.
+func (parser Parser) readLines(r *bufio.Reader) ([]string, error) {
+	var lines []string
+	var line strings.Builder
+	for {
+		chunk, err := r.ReadString('\n')
+		line.WriteString(chunk)
+		if err != nil {
+			if err != io.EOF {
+				return lines, err
+			}
+			if line.Len() != 0 {
+				lines = append(lines, line.String())
+			}
+			if len(lines) == 0 {
+				return nil, io.EOF
+			}
+			// a missing final blank line is tolerated as in ParseRequest
+			return lines, nil
+		}
+		current := line.String()
+		if !strings.HasSuffix(current, "\r\n") {
+			// bare LF inside a line
+			continue
+		}
+		line.Reset()
+		current = current[:len(current)-2]
+		if parser.isBlank(current) {
+			if len(lines) == 0 {
+				// skip blank lines between messages
+				continue
+			}
+			return lines, nil
+		}
+		lines = append(lines, current)
+	}
+}
+
+func (parser Parser) isBlank(line string) bool {
+	if parser.Lenient {
+		return strings.TrimRight(line, " \t") == ""
+	}
+	return line == ""
+}
+
+// ReadRequest reads one SHIORI/x.x Request Message from r
+func ReadRequest(r *bufio.Reader) (Request, error) {
+	return Parser{}.ReadRequest(r)
+}
+
+// ReadRequest reads one SHIORI/x.x Request Message from r.
+// It returns io.EOF if r ends before the message begins.
+func (parser Parser) ReadRequest(r *bufio.Reader) (Request, error) {
+	lines, err := parser.readLines(r)
+	if err != nil {
+		return Request{Protocol: SHIORI}, err
+	}
+	if !parser.hooked() {
+		return parser.parseRequestLines(parser.normalizeLines(lines))
+	}
+	// time the parse only, not waiting for r
+	start := time.Now()
+	raw := strings.Join(lines, "\r\n") + "\r\n\r\n"
+	request, err := parser.parseRequestLines(parser.normalizeLines(lines))
+	parser.notify(ParseEvent{Raw: raw, Request: &request, Err: err, Duration: time.Since(start)})
+	return request, err
+}
+
+// ReadResponse reads one SHIORI/x.x Response Message from r
+func ReadResponse(r *bufio.Reader) (Response, error) {
+	return Parser{}.ReadResponse(r)
+}
+
+// ReadResponse reads one SHIORI/x.x Response Message from r.
+// It returns io.EOF if r ends before the message begins.
+func (parser Parser) ReadResponse(r *bufio.Reader) (Response, error) {
+	lines, err := parser.readLines(r)
+	if err != nil {
+		return Response{Protocol: SHIORI}, err
+	}
+	if !parser.hooked() {
+		return parser.parseResponseLines(parser.normalizeLines(lines))
+	}
+	// time the parse only, not waiting for r
+	start := time.Now()
+	raw := strings.Join(lines, "\r\n") + "\r\n\r\n"
+	response, err := parser.parseResponseLines(parser.normalizeLines(lines))
+	parser.notify(ParseEvent{Raw: raw, Response: &response, Err: err, Duration: time.Since(start)})
+	return response, err
+}
+
+// countingWriter writes strings to w counting bytes and keeping the first error
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (writer *countingWriter) WriteString(str string) (int, error) {
+	if writer.err != nil {
+		return 0, writer.err
+	}
+	n, err := io.WriteString(writer.w, str)
+	writer.n += int64(n)
+	writer.err = err
+	return n, err
+}
+
+// WriteTo writes SHIORI/x.x Request Message to w (implements io.WriterTo).
+// Wrap w with bufio.Writer to avoid small writes.
+func (request Request) WriteTo(w io.Writer) (int64, error) {
+	writer := &countingWriter{w: w}
+	writer.WriteString(request.Method.String() + " " + request.Protocol.String() + "/" + request.Version + "\r\n")
+	Headers(request.Headers).writeTo(writer)
+	writer.WriteString("\r\n")
+	return writer.n, writer.err
+}
+
+// WriteTo writes SHIORI/x.x Response Message to w (implements io.WriterTo).
+// Wrap w with bufio.Writer to avoid small writes.
+func (response Response) WriteTo(w io.Writer) (int64, error) {
+	writer := &countingWriter{w: w}
+	writer.WriteString(response.Protocol.String() + "/" + response.Version + " " + strconv.Itoa(response.Code) + " " + response.Message() + "\r\n")
+	Headers(response.Headers).writeTo(writer)
+	writer.WriteString("\r\n")
+	return writer.n, writer.err
+}
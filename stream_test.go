@@ -0,0 +1,38 @@
+package shiori
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadRequestTerminator(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  Parser
+		message string
+		methods []Method
+	}{
+		{"strict", Parser{}, "GET SHIORI/3.0\r\nID: OnBoot\r\n\r\nNOTIFY SHIORI/3.0\r\nID: x\r\n\r\n", []Method{GET, NOTIFY}},
+		{"lenient space", Parser{Lenient: true}, "GET SHIORI/3.0\r\nID: OnBoot\r\n \r\nNOTIFY SHIORI/3.0\r\nID: x\r\n\r\n", []Method{GET, NOTIFY}},
+		{"lenient tab between messages", Parser{Lenient: true}, "GET SHIORI/3.0\r\nID: OnBoot\r\n\r\n\t\r\nNOTIFY SHIORI/3.0\r\nID: x\r\n\t\r\n", []Method{GET, NOTIFY}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(test.message))
+			for _, method := range test.methods {
+				request, err := test.parser.ReadRequest(r)
+				if err != nil {
+					t.Fatalf("ReadRequest() error = %v", err)
+				}
+				if request.Method != method {
+					t.Errorf("Method = %v, want %v", request.Method, method)
+				}
+			}
+			if _, err := test.parser.ReadRequest(r); err != io.EOF {
+				t.Errorf("ReadRequest() at end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
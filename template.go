@@ -0,0 +1,103 @@
+package shiori
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"strings"
+	"text/template"
+)
+
+// TemplateVariant is one candidate response of an event
+type TemplateVariant struct {
+	// Weight is relative probability of the variant (1 when 0)
+	Weight int `json:"weight"`
+	// Script is text/template of Value header executed with *Request, e.g. "\\h\\s[0]Hello, {{.Reference 0}}.\\e"
+	Script string `json:"script"`
+}
+
+// Templates maps event IDs to variants of templated responses, e.g. in JSON
+//
+//	{
+//		"OnBoot": [{"weight": 3, "script": "\\h\\s[0]Hello.\\e"}, {"script": "\\h\\s[0]Hi.\\e"}],
+//		"OnMouse*": [{"script": "\\h\\s[0]Don't touch.\\e"}],
+//		"*": [{"script": ""}]
+//	}
+//
+// IDs may be wildcards as in Mux.Handle, so "*" gives fallback content for any other event.
+type Templates map[string][]TemplateVariant
+
+// LoadTemplates reads Templates in JSON
+func LoadTemplates(r io.Reader) (Templates, error) {
+	var templates Templates
+	if err := json.NewDecoder(r).Decode(&templates); err != nil {
+		return nil, TemplateError("broken templates: " + err.Error())
+	}
+	return templates, nil
+}
+
+// Handler compiles the templates into Handler answering 200 OK with a variant chosen by weight.
+// Events without template and scripts which execute to empty string are answered with 204 No Content.
+//
+// Set the result as Fallback of a Mux to layer custom handlers on top of the templates.
+func (templates Templates) Handler() (Handler, error) {
+	mux := NewMux()
+	for id, variants := range templates {
+		handler, err := compileVariants(id, variants)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle(id, handler)
+	}
+	return mux, nil
+}
+
+type compiledVariant struct {
+	weight int
+	script *template.Template
+}
+
+func compileVariants(id string, variants []TemplateVariant) (Handler, error) {
+	compiled := make([]compiledVariant, 0, len(variants))
+	total := 0
+	for _, variant := range variants {
+		script, err := template.New(id).Parse(variant.Script)
+		if err != nil {
+			return nil, TemplateError(err.Error())
+		}
+		weight := variant.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+		compiled = append(compiled, compiledVariant{weight: weight, script: script})
+	}
+	return HandlerFunc(func(request Request) Response {
+		if total == 0 {
+			return noContent(request)
+		}
+		chosen := rand.Intn(total)
+		for _, variant := range compiled {
+			if chosen >= variant.weight {
+				chosen -= variant.weight
+				continue
+			}
+			var builder strings.Builder
+			if err := variant.script.Execute(&builder, &request); err != nil {
+				return InternalError(TemplateError(err.Error()), WithDefaultsFrom(request))
+			}
+			if builder.Len() == 0 {
+				return noContent(request)
+			}
+			return OK(builder.String(), WithDefaultsFrom(request))
+		}
+		return noContent(request)
+	}), nil
+}
+
+// TemplateError is broken template or failure executing it
+type TemplateError string
+
+func (err TemplateError) Error() string {
+	return "TemplateError: " + string(err)
+}
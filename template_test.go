@@ -0,0 +1,60 @@
+package shiori
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testTemplates = `{
+	"OnBoot": [{"weight": 3, "script": "\\h\\s[0]Hello, {{.Reference 0}}.\\e"}, {"weight": 0, "script": "\\h\\s[0]Hello, {{.Reference 0}}.\\e"}],
+	"OnMouse*": [{"script": "\\h\\s[0]Don't touch.\\e"}],
+	"OnClose": [{"script": ""}],
+	"OnBroken": [{"script": "{{.Reference}}"}]
+}`
+
+func TestTemplatesHandler(t *testing.T) {
+	templates, err := LoadTemplates(strings.NewReader(testTemplates))
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	handler, err := templates.Handler()
+	if err != nil {
+		t.Fatalf("Handler() error = %v", err)
+	}
+	tests := []struct {
+		id        string
+		wantCode  int
+		wantValue string
+	}{
+		{"OnBoot", 200, "\\h\\s[0]Hello, master.\\e"},
+		{"OnMouseDoubleClick", 200, "\\h\\s[0]Don't touch.\\e"},
+		{"OnClose", 204, ""},
+		{"OnUnknown", 204, ""},
+		{"OnBroken", 500, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			request := NewRequest(GET, test.id)
+			request.Headers[HeaderCharset] = "UTF-8"
+			request.Headers["Reference0"] = "master"
+			response := handler.Serve(request)
+			if response.Code != test.wantCode || response.Value(0) != test.wantValue {
+				t.Errorf("Serve() = %q, want %d with Value %q", response.String(), test.wantCode, test.wantValue)
+			}
+			if response.Charset() != "UTF-8" {
+				t.Errorf("Charset() = %q, want UTF-8", response.Charset())
+			}
+		})
+	}
+}
+
+func TestTemplatesErrors(t *testing.T) {
+	if _, err := LoadTemplates(strings.NewReader(`{"OnBoot": "Hello"}`)); !errors.Is(err, ErrTemplate) {
+		t.Errorf("LoadTemplates() error = %v, want ErrTemplate", err)
+	}
+	templates := Templates{"OnBoot": {{Script: "{{.Reference 0"}}}
+	if _, err := templates.Handler(); !errors.Is(err, ErrTemplate) {
+		t.Errorf("Handler() error = %v, want ErrTemplate", err)
+	}
+}
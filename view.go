@@ -0,0 +1,96 @@
+package shiori
+
+import "strconv"
+
+// Clone copies Headers
+func (headers Headers) Clone() Headers {
+	cloned := make(Headers, len(headers))
+	for key, value := range headers {
+		cloned[key] = value
+	}
+	return cloned
+}
+
+// Clone copies Request including its headers
+func (request Request) Clone() Request {
+	request.Headers = RequestHeaders(Headers(request.Headers).Clone())
+	return request
+}
+
+// RequestView is read-only view of Request which is safe to share across goroutines.
+// The zero RequestView views an empty Request.
+type RequestView struct {
+	request *Request
+}
+
+// zeroRequest is viewed by the zero RequestView
+var zeroRequest Request
+
+// frozen returns the viewed request
+func (view RequestView) frozen() *Request {
+	if view.request == nil {
+		return &zeroRequest
+	}
+	return view.request
+}
+
+// Freeze makes RequestView from Request without copying.
+// The frozen Request (and its Headers) must not be modified afterwards.
+func (request *Request) Freeze() RequestView {
+	return RequestView{request: request}
+}
+
+// Method of the request
+func (view RequestView) Method() Method {
+	return view.frozen().Method
+}
+
+// Protocol of the request
+func (view RequestView) Protocol() Protocol {
+	return view.frozen().Protocol
+}
+
+// Version of the request
+func (view RequestView) Version() string {
+	return view.frozen().Version
+}
+
+// Header gets header value and whether it exists
+func (view RequestView) Header(name string) (string, bool) {
+	value, ok := view.frozen().Headers[name]
+	return value, ok
+}
+
+// Charset header
+func (view RequestView) Charset() string {
+	return view.frozen().Headers[HeaderCharset]
+}
+
+// Sender header
+func (view RequestView) Sender() string {
+	return view.frozen().Headers[HeaderSender]
+}
+
+// Reference gets Reference* header
+func (view RequestView) Reference(i int) string {
+	return view.frozen().Headers[HeaderReferencePrefix+strconv.Itoa(i)]
+}
+
+// ReferenceOK gets Reference* header and whether it exists (distinguishes absent from empty)
+func (view RequestView) ReferenceOK(i int) (string, bool) {
+	return view.frozen().ReferenceOK(i)
+}
+
+// References gets all Reference* headers by index; absent indexes are absent from the map
+func (view RequestView) References() map[int]string {
+	return view.frozen().References()
+}
+
+// Thaw returns mutable copy of the request (copy-on-write)
+func (view RequestView) Thaw() Request {
+	return view.frozen().Clone()
+}
+
+func (view RequestView) String() string {
+	return view.frozen().String()
+}
@@ -0,0 +1,48 @@
+package shiori
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRequestViewZero(t *testing.T) {
+	var view RequestView
+	if view.Method() != InvalidMethod || view.Version() != "" || view.Charset() != "" || view.Reference(0) != "" {
+		t.Errorf("zero RequestView is not empty: %q", view.String())
+	}
+	if _, ok := view.Header(HeaderID); ok {
+		t.Error("zero RequestView has ID header")
+	}
+	if len(view.References()) != 0 {
+		t.Errorf("References() = %v, want none", view.References())
+	}
+	thawed := view.Thaw()
+	thawed.Headers[HeaderID] = "OnBoot"
+	if _, ok := view.Header(HeaderID); ok {
+		t.Error("Thaw() of zero RequestView shares headers")
+	}
+}
+
+func TestRequestViewThaw(t *testing.T) {
+	request := NewRequest(NOTIFY, "OnBoot", "master")
+	view := request.Freeze()
+	thawed := view.Thaw()
+	thawed.Method = GET
+	thawed.Headers["Reference0"] = "changed"
+	if view.Method() != NOTIFY || view.Reference(0) != "master" || request.Reference(0) != "master" {
+		t.Errorf("Thaw() shares the request: %q", view.String())
+	}
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if reference, ok := view.ReferenceOK(0); !ok || reference != "master" {
+				t.Errorf("ReferenceOK(0) = %q, %v", reference, ok)
+			}
+			thawed := view.Thaw()
+			thawed.Headers["Reference0"] = "worker"
+		}()
+	}
+	wait.Wait()
+}